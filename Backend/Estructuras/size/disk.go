@@ -6,13 +6,14 @@ import (
 	"unsafe"
 )
 
-func SizeEBR() int32 { //30 bytes
+func SizeEBR() int32 { //38 bytes
 	a01 := unsafe.Sizeof(structures.EBR{}.Part_mount)
 	a01 += unsafe.Sizeof(structures.EBR{}.Part_fit)
 	a01 += unsafe.Sizeof(structures.EBR{}.Part_start)
 	a01 += unsafe.Sizeof(structures.EBR{}.Part_s)
 	a01 += unsafe.Sizeof(structures.EBR{}.Part_next)
 	a01 += unsafe.Sizeof(structures.EBR{}.Name)
+	a01 += unsafe.Sizeof(structures.EBR{}.Part_correlative)
 	return int32(a01)
 }
 
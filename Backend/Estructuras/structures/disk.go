@@ -1,12 +1,13 @@
 package structures
 
 type EBR struct {
-	Part_mount int8
-	Part_fit   byte
-	Part_start int32
-	Part_s     int32
-	Part_next  int32
-	Name       [16]byte
+	Part_mount       int8
+	Part_fit         byte
+	Part_start       int32
+	Part_s           int32
+	Part_next        int32
+	Name             [16]byte
+	Part_correlative int32
 }
 
 // PARTICIONES PRIMARIAS
@@ -1,11 +1,11 @@
 package structures
 
 /* =========================
-   SUPER BLOQUE (EXT2)
+   SUPER BLOQUE (EXT2 / EXT3)
 ========================= */
 
 type SuperBlock struct {
-	S_filesystem_type   int32 // 2 = EXT2
+	S_filesystem_type   int32 // 2 = EXT2, 3 = EXT3
 	S_inodes_count      int32
 	S_blocks_count      int32
 	S_free_blocks_count int32
@@ -39,3 +39,30 @@ type Inode struct {
 	I_type  byte    // 0 = carpeta | 1 = archivo
 	I_perm  [3]byte // permisos UGO
 }
+
+/* =========================
+   JOURNAL (EXT3)
+========================= */
+
+// Information describe una operación registrada en el journal
+type Information struct {
+	I_operation [10]byte
+	I_path      [32]byte
+	I_content   [64]byte
+	I_date      int32
+	I_uid       int32 // usuario y grupo de quien la ejecutó
+	I_gid       int32
+}
+
+// Journal es una entrada del área que sigue al SuperBloque en EXT3.
+// Una ruta o contenido que no cabe continúa en las entradas siguientes
+// (J_more = 1 en todas menos la última). J_path_s y J_content_s indican
+// cuántos bytes de I_path e I_content son válidos, así el contenido puede
+// incluir bytes en cero.
+type Journal struct {
+	J_count     int32 // número de entrada, 0 = libre
+	J_more      byte
+	J_path_s    byte
+	J_content_s byte
+	J_content   Information
+}
@@ -5,7 +5,7 @@ import (
 	"strings"
 )
 
-type Handler func(comando string, props map[string]string) (string, bool)
+type Handler func(ctx *CommandContext, comando string, props map[string]string) (string, bool)
 
 type CommandDef struct {
 	Allowed  map[string]bool
@@ -35,9 +35,10 @@ var commands = map[string]CommandDef{
 		Allowed: map[string]bool{
 			"size": true, "unit": true, "diskname": true,
 			"type": true, "fit": true, "name": true,
+			"delete": true, "add": true,
 		},
-		Required: []string{"size", "diskname", "name"},
-		Defaults: map[string]string{"unit": "K", "type": "P", "fit": "FF"},
+		Required: []string{"diskname", "name"},
+		Defaults: map[string]string{"unit": "K", "type": "P"},
 		Run:      fdiskExecute,
 	},
 	"mount": {
@@ -48,6 +49,14 @@ var commands = map[string]CommandDef{
 		Defaults: map[string]string{},
 		Run:      mountExecute,
 	},
+	"unmount": {
+		Allowed: map[string]bool{
+			"id": true,
+		},
+		Required: []string{"id"},
+		Defaults: map[string]string{},
+		Run:      unmountExecute,
+	},
 	"mounted": {
 		Allowed:  map[string]bool{},
 		Required: []string{},
@@ -56,21 +65,40 @@ var commands = map[string]CommandDef{
 	},
 	"mkfs": {
 		Allowed: map[string]bool{
-			"id": true, "type": true,
+			"id": true, "type": true, "fs": true,
 		},
 		Required: []string{"id"},
-		Defaults: map[string]string{"type": "full"},
-		Run: func(_ string, props map[string]string) (string, bool) {
+		Defaults: map[string]string{"type": "full", "fs": "2fs"},
+		Run: func(_ *CommandContext, _ string, props map[string]string) (string, bool) {
 
 			mkfs := MKFS{
 				Id:   props["id"],
 				Type: strings.ToLower(props["type"]),
+				Fs:   strings.ToLower(props["fs"]),
 			}
 
-			mkfs.Execute()
+			if err := mkfs.Execute(); err != nil {
+				return err.Error(), true
+			}
 			return "MKFS ejecutado correctamente", false
 		},
 	},
+	"loss": {
+		Allowed: map[string]bool{
+			"id": true,
+		},
+		Required: []string{"id"},
+		Defaults: map[string]string{},
+		Run:      lossExecute,
+	},
+	"recovery": {
+		Allowed: map[string]bool{
+			"id": true,
+		},
+		Required: []string{"id"},
+		Defaults: map[string]string{},
+		Run:      recoveryExecute,
+	},
 	"login": {
 		Allowed: map[string]bool{
 			"user": true, "pass": true, "id": true,
@@ -101,6 +129,30 @@ var commands = map[string]CommandDef{
 		Defaults: map[string]string{},
 		Run:      mkusrExecute,
 	},
+	"chgrp": {
+		Allowed: map[string]bool{
+			"user": true, "grp": true,
+		},
+		Required: []string{"user", "grp"},
+		Defaults: map[string]string{},
+		Run:      chgrpExecute,
+	},
+	"rmusr": {
+		Allowed: map[string]bool{
+			"user": true,
+		},
+		Required: []string{"user"},
+		Defaults: map[string]string{},
+		Run:      rmusrExecute,
+	},
+	"rmgrp": {
+		Allowed: map[string]bool{
+			"name": true,
+		},
+		Required: []string{"name"},
+		Defaults: map[string]string{},
+		Run:      rmgrpExecute,
+	},
 	"cat": {
 		Allowed: map[string]bool{
 			"file1": true,
@@ -123,6 +175,7 @@ var commands = map[string]CommandDef{
 			"path": true,
 			"size": true,
 			"r":    true,
+			"cont": true,
 		},
 		Required: []string{"path"},
 		Defaults: map[string]string{
@@ -130,10 +183,84 @@ var commands = map[string]CommandDef{
 		},
 		Run: mkfileExecute,
 	},
+	"remove": {
+		Allowed: map[string]bool{
+			"path": true,
+		},
+		Required: []string{"path"},
+		Defaults: map[string]string{},
+		Run:      removeExecute,
+	},
+	"edit": {
+		Allowed: map[string]bool{
+			"path":      true,
+			"contenido": true,
+			"append":    true,
+		},
+		Required: []string{"path", "contenido"},
+		Defaults: map[string]string{},
+		Run:      editExecute,
+	},
+	"rename": {
+		Allowed: map[string]bool{
+			"path": true,
+			"name": true,
+		},
+		Required: []string{"path", "name"},
+		Defaults: map[string]string{},
+		Run:      renameExecute,
+	},
+	"copy": {
+		Allowed: map[string]bool{
+			"path":    true,
+			"destino": true,
+		},
+		Required: []string{"path", "destino"},
+		Defaults: map[string]string{},
+		Run:      copyExecute,
+	},
+	"move": {
+		Allowed: map[string]bool{
+			"path":    true,
+			"destino": true,
+		},
+		Required: []string{"path", "destino"},
+		Defaults: map[string]string{},
+		Run:      moveExecute,
+	},
+	"find": {
+		Allowed: map[string]bool{
+			"path": true,
+			"name": true,
+		},
+		Required: []string{"path", "name"},
+		Defaults: map[string]string{},
+		Run:      findExecute,
+	},
+	"chmod": {
+		Allowed: map[string]bool{
+			"path": true,
+			"ugo":  true,
+			"r":    true,
+		},
+		Required: []string{"path", "ugo"},
+		Defaults: map[string]string{},
+		Run:      chmodExecute,
+	},
+	"chown": {
+		Allowed: map[string]bool{
+			"path":    true,
+			"usuario": true,
+			"r":       true,
+		},
+		Required: []string{"path", "usuario"},
+		Defaults: map[string]string{},
+		Run:      chownExecute,
+	},
 }
 
 // COMANDOS
-func diskCommandProps(comando string, instrucciones []string) (string, bool) {
+func diskCommandProps(ctx *CommandContext, comando string, instrucciones []string) (string, bool) {
 
 	cmd := strings.ToLower(comando)
 	def, ok := commands[cmd]
@@ -203,7 +330,7 @@ func diskCommandProps(comando string, instrucciones []string) (string, bool) {
 	}
 
 	// ERROR
-	msg, err := def.Run(comando, props)
+	msg, err := def.Run(ctx, comando, props)
 	if err {
 		return msg, true
 	}
@@ -211,6 +338,6 @@ func diskCommandProps(comando string, instrucciones []string) (string, bool) {
 	return msg, false
 }
 
-func DiskExecuteCommanWithProps(command string, parameters []string) (string, bool) {
-	return diskCommandProps(command, parameters)
+func DiskExecuteCommanWithProps(ctx *CommandContext, command string, parameters []string) (string, bool) {
+	return diskCommandProps(ctx, command, parameters)
 }
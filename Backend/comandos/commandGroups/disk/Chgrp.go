@@ -0,0 +1,76 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func chgrpExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de usuarios: chgrp")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	if ctx.Session.User != "root" {
+		return "❌ Error: solo el usuario root puede cambiar el grupo de un usuario", true
+	}
+
+	userName := strings.TrimSpace(props["user"])
+	groupName := strings.TrimSpace(props["grp"])
+	if userName == "" || groupName == "" {
+		return "❌ Error: los parámetros user y grp son obligatorios", true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	store, err := loadUsersStore(file, sb)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if err := store.setUserGroup(userName, groupName); err != nil {
+		return err.Error(), true
+	}
+
+	if err := store.save(file, int64(part.Start), &sb); err != nil {
+		return err.Error(), true
+	}
+
+	// Las sesiones abiertas del usuario pasan a usar el grupo nuevo
+	gid := store.findGroup(groupName).ID
+	updateUserSessions(part.Id, userName, func(s *Session) bool {
+		s.Group = groupName
+		s.Gid = gid
+		return true
+	})
+	if ctx.Session.User == userName {
+		ctx.Session = ResolveSession(ctx.Session.Token)
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "chgrp", usersFilePath, userName+","+groupName); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ Usuario '%s' cambiado al grupo '%s'", userName, groupName), false
+}
@@ -0,0 +1,100 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func chmodExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de permisos: chmod")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	targetPath := strings.TrimSpace(props["path"])
+	ugo := strings.TrimSpace(props["ugo"])
+	if targetPath == "" || ugo == "" {
+		return "❌ Error: los parámetros path y ugo son obligatorios", true
+	}
+
+	perm, err := parseUGO(ugo)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	_, recursive := props["r"]
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	inodeIndex, err := resolvePath(ctx.Session, file, sb, 0, targetPath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err.Error(), true
+	}
+	if !isOwner(ctx.Session, inode) {
+		return fmt.Sprintf("❌ Error: solo root o el propietario pueden cambiar permisos de '%s'", targetPath), true
+	}
+
+	skipped, err := applyOwnedTree(ctx.Session, file, sb, inodeIndex, recursive, func(in *structures.Inode) {
+		in.I_perm = perm
+	})
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if skipped > 0 {
+		color.Yellow("⚠ %d elemento(s) omitido(s): no pertenecen a %s", skipped, ctx.Session.User)
+	}
+
+	args := ugo
+	if recursive {
+		args += " -r"
+	}
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "chmod", path.Clean("/"+targetPath), args); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ Permisos de '%s' cambiados a %s", targetPath, ugo), false
+}
+
+// parseUGO convierte "764" en los permisos de usuario, grupo y otros
+func parseUGO(ugo string) ([3]byte, error) {
+	var perm [3]byte
+	if len(ugo) != 3 {
+		return perm, fmt.Errorf("❌ Error: ugo debe tener 3 dígitos entre 0 y 7")
+	}
+
+	for i := 0; i < 3; i++ {
+		if ugo[i] < '0' || ugo[i] > '7' {
+			return perm, fmt.Errorf("❌ Error: ugo debe tener 3 dígitos entre 0 y 7")
+		}
+		perm[i] = ugo[i] - '0'
+	}
+	return perm, nil
+}
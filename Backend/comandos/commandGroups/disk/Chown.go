@@ -0,0 +1,89 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func chownExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de permisos: chown")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	targetPath := strings.TrimSpace(props["path"])
+	userName := strings.TrimSpace(props["usuario"])
+	if targetPath == "" || userName == "" {
+		return "❌ Error: los parámetros path y usuario son obligatorios", true
+	}
+
+	_, recursive := props["r"]
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	store, err := loadUsersStore(file, sb)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	owner := store.findUser(userName)
+	if owner == nil {
+		return fmt.Sprintf("❌ Error: el usuario '%s' no existe", userName), true
+	}
+
+	inodeIndex, err := resolvePath(ctx.Session, file, sb, 0, targetPath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err.Error(), true
+	}
+	if !isOwner(ctx.Session, inode) {
+		return fmt.Sprintf("❌ Error: solo root o el propietario pueden cambiar el propietario de '%s'", targetPath), true
+	}
+
+	skipped, err := applyOwnedTree(ctx.Session, file, sb, inodeIndex, recursive, func(in *structures.Inode) {
+		in.I_uid = owner.ID
+	})
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if skipped > 0 {
+		color.Yellow("⚠ %d elemento(s) omitido(s): no pertenecen a %s", skipped, ctx.Session.User)
+	}
+
+	args := userName
+	if recursive {
+		args += " -r"
+	}
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "chown", path.Clean("/"+targetPath), args); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ Propietario de '%s' cambiado a %s", targetPath, userName), false
+}
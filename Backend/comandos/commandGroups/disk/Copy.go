@@ -0,0 +1,212 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+func copyExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de archivos: copy")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	sourcePath := strings.TrimSpace(props["path"])
+	destPath := strings.TrimSpace(props["destino"])
+	if sourcePath == "" || destPath == "" {
+		return "❌ Error: los parámetros path y destino son obligatorios", true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
+	cleanPath := path.Clean("/" + sourcePath)
+	if cleanPath == "/" {
+		return "❌ Error: no se puede copiar la raíz", true
+	}
+
+	sourceInode, err := resolvePath(ctx.Session, file, sb, 0, cleanPath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	destInode, err := resolvePath(ctx.Session, file, sb, 0, destPath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	dest, err := ReadInode(file, sb, destInode)
+	if err != nil {
+		return err.Error(), true
+	}
+	if dest.I_type != 0 {
+		return fmt.Sprintf("❌ Error: '%s' no es una carpeta", destPath), true
+	}
+
+	source, err := ReadInode(file, sb, sourceInode)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if source.I_type == 0 && isSameOrDescendant(file, sb, sourceInode, destInode) {
+		return "❌ Error: no se puede copiar una carpeta dentro de sí misma", true
+	}
+
+	name := path.Base(cleanPath)
+	if exists, _ := findEntryInDirectory(file, sb, destInode, name); exists {
+		return fmt.Sprintf("❌ Error: ya existe '%s' en '%s'", name, destPath), true
+	}
+
+	if err := checkPermission(ctx.Session, source, permRead, sourcePath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+	if err := checkPermission(ctx.Session, dest, permWrite, destPath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	skipped := []string{}
+	copyInode, err := copyTree(ctx.Session, file, sb, sourceInode, destInode, name, cleanPath, &skipped)
+	if err != nil {
+		// Si falta espacio se descarta la copia parcial
+		if copyInode != -1 {
+			removeTree(file, sb, copyInode)
+			removeEntryFromDirectory(file, sb, destInode, name)
+		}
+		return err.Error(), true
+	}
+
+	for _, p := range skipped {
+		color.Yellow("⚠ '%s' omitido: sin permiso de lectura", p)
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "copy", cleanPath, path.Clean("/"+destPath)); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ '%s' copiado a '%s'", sourcePath, destPath), false
+}
+
+// copyTree copia el inodo (y su contenido) como una entrada nueva de
+// destParent. Devuelve el inodo creado aunque falle a mitad de la copia
+// para que el llamador pueda revertirla.
+func copyTree(
+	session *Session,
+	file *os.File,
+	sb structures.SuperBlock,
+	sourceInode int32,
+	destParent int32,
+	name string,
+	sourcePath string,
+	skipped *[]string,
+) (int32, error) {
+
+	source, err := ReadInode(file, sb, sourceInode)
+	if err != nil {
+		return -1, err
+	}
+
+	if source.I_type == 0 {
+		newInode, err := createDirectory(session, file, sb, destParent, name)
+		if err != nil {
+			return -1, err
+		}
+
+		copied, err := ReadInode(file, sb, newInode)
+		if err != nil {
+			return newInode, err
+		}
+		copied.I_uid = session.Uid
+		copied.I_gid = session.Gid
+		copied.I_perm = source.I_perm
+		if err := WriteInode(file, sb, newInode, copied); err != nil {
+			return newInode, err
+		}
+
+		for _, entry := range directoryEntries(file, sb, source) {
+			childName := strings.TrimRight(string(entry.B_name[:]), "\x00")
+			childPath := path.Join(sourcePath, childName)
+
+			child, err := ReadInode(file, sb, entry.B_inodo)
+			if err != nil {
+				return newInode, err
+			}
+			if !hasPermission(session, child, permRead) {
+				*skipped = append(*skipped, childPath)
+				continue
+			}
+
+			if _, err := copyTree(session, file, sb, entry.B_inodo, newInode, childName, childPath, skipped); err != nil {
+				return newInode, err
+			}
+		}
+
+		return newInode, nil
+	}
+
+	data, err := readInodeData(file, sb, source)
+	if err != nil {
+		return -1, err
+	}
+
+	newInode := FindFreeInode(file, sb)
+	if newInode == -1 {
+		return -1, fmt.Errorf("❌ Error: no hay inodos libres")
+	}
+
+	now := int32(time.Now().Unix())
+
+	inode := structures.Inode{
+		I_uid:   session.Uid,
+		I_gid:   session.Gid,
+		I_atime: now,
+		I_ctime: now,
+		I_mtime: now,
+		I_type:  1,
+		I_perm:  source.I_perm,
+	}
+	for i := 0; i < 15; i++ {
+		inode.I_block[i] = -1
+	}
+
+	WriteInode(file, sb, newInode, inode)
+	MarkBitmap(file, sb.S_bm_inode_start, newInode)
+
+	if err := writeInodeData(file, sb, newInode, data); err != nil {
+		cleanFileBlocks(file, sb, newInode)
+		UnmarkBitmap(file, sb.S_bm_inode_start, newInode)
+		return -1, err
+	}
+
+	if err := addEntryToDirectory(file, sb, destParent, name, newInode); err != nil {
+		cleanFileBlocks(file, sb, newInode)
+		UnmarkBitmap(file, sb.S_bm_inode_start, newInode)
+		return -1, err
+	}
+
+	return newInode, nil
+}
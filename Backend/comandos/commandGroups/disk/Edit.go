@@ -0,0 +1,95 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func editExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de archivos: edit")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	filePath := strings.TrimSpace(props["path"])
+	hostPath := strings.TrimSpace(props["contenido"])
+
+	if filePath == "" || hostPath == "" {
+		return "❌ Error: los parámetros path y contenido son obligatorios", true
+	}
+
+	appendFlag := false
+	if val, ok := props["append"]; ok {
+		if val != "" {
+			return "❌ Error: el parámetro -append no recibe valores", true
+		}
+		appendFlag = true
+	}
+
+	content, err := readHostFile(hostPath)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
+	inodeIndex, err := resolvePath(ctx.Session, file, sb, 0, filePath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if inode.I_type != 1 {
+		return fmt.Sprintf("❌ Error: '%s' no es un archivo", filePath), true
+	}
+
+	if err := checkPermission(ctx.Session, inode, permWrite, filePath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	if appendFlag {
+		current, err := readInodeData(file, sb, inode)
+		if err != nil {
+			return err.Error(), true
+		}
+		content = append(current, content...)
+	}
+
+	if err := writeInodeData(file, sb, inodeIndex, content); err != nil {
+		return err.Error(), true
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "edit", path.Clean("/"+filePath), string(content)); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ Archivo '%s' editado correctamente (%d bytes)", filePath, len(content)), false
+}
@@ -2,8 +2,10 @@ package disk
 
 import (
 	"Proyecto/Estructuras/size"
+	"Proyecto/Estructuras/structures"
 	"Proyecto/comandos/utils"
 	"encoding/binary"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -12,7 +14,39 @@ import (
 )
 
 // P = Primario
-func fdiskExecute(comando string, parametros map[string]string) (string, bool) {
+func fdiskExecute(_ *CommandContext, comando string, parametros map[string]string) (string, bool) {
+
+	// fdisk reescribe el MBR y los EBR: se serializa por disco. Al borrar o
+	// redimensionar puede cambiar un bitmap, así que se descarta su caché.
+	// El sufijo .mia se agrega antes para usar la misma ruta que mount.
+	if diskName, er, _ := utils.TieneDiskName(parametros["diskname"]); !er {
+		if !strings.HasSuffix(strings.ToLower(diskName), ".mia") {
+			diskName += ".mia"
+		}
+		parametros["diskname"] = diskName
+
+		defer LockDisk(utils.DirectorioDisco + diskName)()
+		dropBitmaps(utils.DirectorioDisco + diskName)
+	}
+
+	_, esDelete := parametros["delete"]
+	_, esAdd := parametros["add"]
+
+	if esDelete && esAdd {
+		return "Los parámetros -delete y -add no pueden usarse juntos", true
+	}
+
+	if esDelete {
+		return fdiskDeleteExecute(parametros)
+	}
+
+	if esAdd {
+		return fdiskAddExecute(comando, parametros)
+	}
+
+	if strings.TrimSpace(parametros["size"]) == "" {
+		return "Parámetro obligatorio faltante: size", true
+	}
 
 	tamanio, er, strError := utils.TieneSize(comando, parametros["size"])
 	if er {
@@ -34,9 +68,13 @@ func fdiskExecute(comando string, parametros map[string]string) (string, bool) {
 		return strError, er
 	}
 
-	tipoFit, er, strError := utils.TieneFit("fdisk", parametros["fit"])
-	if er {
-		return strError, er
+	// Sin -fit se hereda el ajuste del disco (o de la extendida para lógicas)
+	var tipoFit byte
+	if strings.TrimSpace(parametros["fit"]) != "" {
+		tipoFit, er, strError = utils.TieneFit("fdisk", parametros["fit"])
+		if er {
+			return strError, er
+		}
 	}
 
 	nombreParticion, er, strError := utils.TieneName(parametros["name"])
@@ -58,14 +96,11 @@ func fdiskCreate(tamanio int32, unidad byte, diskName string, tipo byte, tipoFit
 	path := utils.DirectorioDisco + diskName
 
 	switch tipo {
-	case 'P':
+	case 'P', 'E':
 		return particionPrimaria(path, nombreParticion, tipo, tamanio, tipoFit, unidad)
 
-	case 'E':
-		return "Particiones extendidas aún no implementadas", true
-
 	case 'L':
-		return "Particiones lógicas aún no implementadas", true
+		return particionLogica(path, nombreParticion, tamanio, tipoFit, unidad)
 
 	default:
 		return "Tipo de partición desconocido", true
@@ -100,14 +135,30 @@ func particionPrimaria(ubicacionArchivo string, nombreParticion string, tipo byt
 		return "No hay espacio para más particiones primarias", true
 	}
 
+	if tipo == 'E' {
+		if _, existe := utils.ObtenerParticionExtendida(mbr); existe {
+			return "Ya existe una partición extendida en el disco", true
+		}
+	}
+
 	// Nombre duplicado
 	nombreExistente, msg := utils.ExisteNombreParticion(ubicacionArchivo, nombreParticion)
 	if nombreExistente {
 		return msg, true
 	}
 
-	// Espacio
-	if !utils.ExisteEspacioDisponible(tamanioDisco, ubicacionArchivo, unidad, int32(pos)) {
+	if tipoFit == 0 {
+		tipoFit = mbr.Dsk_fit
+	}
+
+	tamanio := utils.ObtenerTamanioDisco(tamanioDisco, unidad)
+	if tamanio <= 0 {
+		return "El tamaño de la partición es inválido", true
+	}
+
+	// Espacio: se elige el hueco según el ajuste solicitado
+	inicio, hayEspacio := utils.SeleccionarEspacio(utils.EspaciosLibresDisco(mbr), tamanio, tipoFit)
+	if !hayEspacio {
 		return "Espacio insuficiente en el disco", true
 	}
 
@@ -117,16 +168,11 @@ func particionPrimaria(ubicacionArchivo string, nombreParticion string, tipo byt
 	particion.Part_status = 0
 	particion.Part_name = [16]byte(utils.ConvertirStringAByte(nombreParticion, 16))
 	particion.Part_correlative = utils.ObtenerDiskSignature()
-	particion.Part_s = utils.ObtenerTamanioDisco(tamanioDisco, unidad)
-
-	if pos == 0 {
-		particion.Part_start = size.SizeMBR()
-	} else {
-		particion.Part_start = mbr.Mbr_partitions[pos-1].Part_start +
-			mbr.Mbr_partitions[pos-1].Part_s
-	}
+	particion.Part_s = tamanio
+	particion.Part_start = inicio
 
 	mbr.Mbr_partitions[pos] = particion
+	utils.OrdenarParticiones(&mbr)
 
 	file, err := os.OpenFile(ubicacionArchivo, os.O_RDWR, 0666)
 	if err != nil {
@@ -139,8 +185,23 @@ func particionPrimaria(ubicacionArchivo string, nombreParticion string, tipo byt
 		return "Error al escribir el MBR", true
 	}
 
+	tipoTexto := "primaria"
+
+	// La extendida inicia con un EBR vacío que encabeza la cadena de lógicas
+	if tipo == 'E' {
+		tipoTexto = "extendida"
+
+		ebr := utils.NuevoEBRVacio()
+		ebr.Part_fit = tipoFit
+
+		file.Seek(int64(particion.Part_start), 0)
+		if err := binary.Write(file, binary.LittleEndian, &ebr); err != nil {
+			return "Error al escribir el EBR inicial", true
+		}
+	}
+
 	color.Green("-----------------------------------------------------------")
-	color.Blue("Partición primaria creada exitosamente")
+	color.Blue("Partición " + tipoTexto + " creada exitosamente")
 	color.Blue("Nombre: " + nombreParticion)
 	color.Blue("Inicio: " + strconv.Itoa(int(particion.Part_start)))
 	color.Blue("Tamaño: " + strconv.Itoa(int(particion.Part_s)))
@@ -148,3 +209,436 @@ func particionPrimaria(ubicacionArchivo string, nombreParticion string, tipo byt
 
 	return "", false
 }
+
+func particionLogica(ubicacionArchivo string, nombreParticion string, tamanioDisco int32, tipoFit byte, unidad byte) (string, bool) {
+
+	if !utils.ExisteArchivo("FDISK", ubicacionArchivo) {
+		color.Yellow("[FDISK]: Disco <<" + ubicacionArchivo + ">> no encontrado")
+		return "Disco no encontrado", true
+	}
+
+	mbr, er, strError := utils.ObtenerEstructuraMBR(ubicacionArchivo)
+	if er {
+		return strError, er
+	}
+
+	extendida, existe := utils.ObtenerParticionExtendida(mbr)
+	if !existe {
+		return "No existe una partición extendida para crear la partición lógica", true
+	}
+
+	// Nombre duplicado (MBR y cadena de EBR)
+	nombreExistente, msg := utils.ExisteNombreParticion(ubicacionArchivo, nombreParticion)
+	if nombreExistente {
+		return msg, true
+	}
+
+	logicas, er, strError := utils.ObtenerListaEBR(ubicacionArchivo, extendida)
+	if er {
+		return strError, er
+	}
+
+	tamanio := utils.ObtenerTamanioDisco(tamanioDisco, unidad)
+	if tamanio <= 0 {
+		return "El tamaño de la partición es inválido", true
+	}
+
+	if tipoFit == 0 {
+		tipoFit = extendida.Part_fit
+	}
+
+	// Cada lógica necesita espacio para su EBR y sus datos
+	libres := utils.EspaciosLibresExtendida(extendida, logicas)
+	posicion, hayEspacio := utils.SeleccionarEspacio(libres, size.SizeEBR()+tamanio, tipoFit)
+	if !hayEspacio {
+		return "Espacio insuficiente en la partición extendida", true
+	}
+
+	ebr := utils.NuevoEBRVacio()
+	ebr.Part_mount = 0
+	ebr.Part_fit = tipoFit
+	ebr.Part_start = posicion + size.SizeEBR()
+	ebr.Part_s = tamanio
+	ebr.Name = [16]byte(utils.ConvertirStringAByte(nombreParticion, 16))
+
+	if posicion == extendida.Part_start {
+		// El EBR inicial está libre: la lógica se escribe sobre él
+		ebr.Part_next = logicas[0].EBR.Part_next
+	} else {
+		// Se enlaza después del último EBR ubicado antes del hueco elegido
+		anterior := logicas[0]
+		for _, l := range logicas {
+			if l.Posicion < posicion {
+				anterior = l
+			}
+		}
+
+		ebr.Part_next = anterior.EBR.Part_next
+		anterior.EBR.Part_next = posicion
+		if er, strError := utils.EscribirEBR(ubicacionArchivo, anterior.Posicion, anterior.EBR); er {
+			return strError, er
+		}
+	}
+
+	if er, strError := utils.EscribirEBR(ubicacionArchivo, posicion, ebr); er {
+		return strError, er
+	}
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Partición lógica creada exitosamente")
+	color.Blue("Nombre: " + nombreParticion)
+	color.Blue("EBR: " + strconv.Itoa(int(posicion)))
+	color.Blue("Inicio: " + strconv.Itoa(int(ebr.Part_start)))
+	color.Blue("Tamaño: " + strconv.Itoa(int(ebr.Part_s)))
+	color.Green("-----------------------------------------------------------")
+
+	return "", false
+}
+
+/* =========================
+   FDISK -DELETE
+========================= */
+
+func fdiskDeleteExecute(parametros map[string]string) (string, bool) {
+
+	modo := strings.ToLower(strings.TrimSpace(parametros["delete"]))
+	if modo != "fast" && modo != "full" {
+		return "El parámetro -delete solo admite fast o full", true
+	}
+
+	diskName, er, strError := utils.TieneDiskName(parametros["diskname"])
+	if er {
+		return strError, er
+	}
+
+	nombreParticion, er, strError := utils.TieneName(parametros["name"])
+	if er {
+		return strError, er
+	}
+
+	return fdiskDelete(utils.DirectorioDisco+diskName, nombreParticion, modo == "full")
+}
+
+func fdiskDelete(ubicacionArchivo string, nombreParticion string, full bool) (string, bool) {
+
+	if !utils.ExisteArchivo("FDISK", ubicacionArchivo) {
+		color.Yellow("[FDISK]: Disco <<" + ubicacionArchivo + ">> no encontrado")
+		return "Disco no encontrado", true
+	}
+
+	mbr, er, strError := utils.ObtenerEstructuraMBR(ubicacionArchivo)
+	if er {
+		return strError, er
+	}
+
+	// El registro de montajes queda fijo desde la verificación de estaMontada
+	// hasta que se reescriben el MBR o los EBR
+	mountedMu.RLock()
+	defer mountedMu.RUnlock()
+
+	file, err := os.OpenFile(ubicacionArchivo, os.O_RDWR, 0666)
+	if err != nil {
+		return "Error al abrir el disco", true
+	}
+	defer file.Close()
+
+	// Primaria o extendida
+	for i, part := range mbr.Mbr_partitions {
+		if part.Part_start == -1 ||
+			!strings.EqualFold(utils.ConvertirByteAString(part.Part_name[:]), nombreParticion) {
+			continue
+		}
+
+		if estaMontada(ubicacionArchivo, nombreParticion) {
+			return "No se puede eliminar una partición montada, desmóntela primero", true
+		}
+
+		if part.Part_type == 'E' {
+			logicas, er, strError := utils.ObtenerListaEBR(ubicacionArchivo, part)
+			if er {
+				return strError, er
+			}
+			for _, l := range logicas {
+				if l.EBR.Part_s > 0 && estaMontada(ubicacionArchivo, utils.ConvertirByteAString(l.EBR.Name[:])) {
+					return "No se puede eliminar la extendida: contiene particiones lógicas montadas", true
+				}
+			}
+		}
+
+		if full {
+			if err := llenarCeros(file, part.Part_start, part.Part_s); err != nil {
+				return "Error al limpiar el espacio de la partición", true
+			}
+		}
+
+		mbr.Mbr_partitions[i] = utils.NuevaPartitionVacia()
+		utils.OrdenarParticiones(&mbr)
+
+		file.Seek(0, 0)
+		if err := binary.Write(file, binary.LittleEndian, &mbr); err != nil {
+			return "Error al escribir el MBR", true
+		}
+
+		color.Green("[FDISK]: Partición %s eliminada", nombreParticion)
+		return fmt.Sprintf("Partición '%s' eliminada correctamente", nombreParticion), false
+	}
+
+	// Lógica
+	extendida, existe := utils.ObtenerParticionExtendida(mbr)
+	if !existe {
+		return fmt.Sprintf("No existe la partición '%s'", nombreParticion), true
+	}
+
+	logicas, er, strError := utils.ObtenerListaEBR(ubicacionArchivo, extendida)
+	if er {
+		return strError, er
+	}
+
+	for i, l := range logicas {
+		if l.EBR.Part_s <= 0 ||
+			!strings.EqualFold(utils.ConvertirByteAString(l.EBR.Name[:]), nombreParticion) {
+			continue
+		}
+
+		if estaMontada(ubicacionArchivo, nombreParticion) {
+			return "No se puede eliminar una partición montada, desmóntela primero", true
+		}
+
+		if full {
+			if err := llenarCeros(file, l.Posicion, l.EBR.Part_start+l.EBR.Part_s-l.Posicion); err != nil {
+				return "Error al limpiar el espacio de la partición", true
+			}
+		}
+
+		if i == 0 {
+			// El EBR inicial no se mueve: queda vacío conservando el enlace
+			vacio := utils.NuevoEBRVacio()
+			vacio.Part_fit = extendida.Part_fit
+			vacio.Part_next = l.EBR.Part_next
+			if er, strError := utils.EscribirEBR(ubicacionArchivo, l.Posicion, vacio); er {
+				return strError, er
+			}
+		} else {
+			anterior := logicas[i-1]
+			anterior.EBR.Part_next = l.EBR.Part_next
+			if er, strError := utils.EscribirEBR(ubicacionArchivo, anterior.Posicion, anterior.EBR); er {
+				return strError, er
+			}
+		}
+
+		color.Green("[FDISK]: Partición lógica %s eliminada", nombreParticion)
+		return fmt.Sprintf("Partición '%s' eliminada correctamente", nombreParticion), false
+	}
+
+	return fmt.Sprintf("No existe la partición '%s'", nombreParticion), true
+}
+
+/* =========================
+   FDISK -ADD
+========================= */
+
+func fdiskAddExecute(comando string, parametros map[string]string) (string, bool) {
+
+	valor, err := strconv.Atoi(strings.TrimSpace(parametros["add"]))
+	if err != nil || valor == 0 {
+		return "El parámetro -add debe ser un entero distinto de 0", true
+	}
+
+	unidad, er, strError := utils.TieneUnit(comando, parametros["unit"])
+	if er {
+		return strError, er
+	}
+
+	diskName, er, strError := utils.TieneDiskName(parametros["diskname"])
+	if er {
+		return strError, er
+	}
+
+	nombreParticion, er, strError := utils.TieneName(parametros["name"])
+	if er {
+		return strError, er
+	}
+
+	return fdiskAdd(utils.DirectorioDisco+diskName, nombreParticion, utils.ObtenerTamanioDisco(int32(valor), unidad))
+}
+
+func fdiskAdd(ubicacionArchivo string, nombreParticion string, cambio int32) (string, bool) {
+
+	if !utils.ExisteArchivo("FDISK", ubicacionArchivo) {
+		color.Yellow("[FDISK]: Disco <<" + ubicacionArchivo + ">> no encontrado")
+		return "Disco no encontrado", true
+	}
+
+	mbr, er, strError := utils.ObtenerEstructuraMBR(ubicacionArchivo)
+	if er {
+		return strError, er
+	}
+
+	file, err := os.OpenFile(ubicacionArchivo, os.O_RDWR, 0666)
+	if err != nil {
+		return "Error al abrir el disco", true
+	}
+	defer file.Close()
+
+	// Primaria o extendida: el límite es la siguiente partición o el fin del disco
+	for i, part := range mbr.Mbr_partitions {
+		if part.Part_start == -1 ||
+			!strings.EqualFold(utils.ConvertirByteAString(part.Part_name[:]), nombreParticion) {
+			continue
+		}
+
+		nuevoTamanio := part.Part_s + cambio
+		if nuevoTamanio <= 0 {
+			return "El tamaño resultante de la partición debe ser mayor a 0", true
+		}
+
+		if cambio > 0 {
+			limite := mbr.Mbr_tamano
+			for _, otra := range mbr.Mbr_partitions {
+				if otra.Part_start > part.Part_start && otra.Part_start < limite {
+					limite = otra.Part_start
+				}
+			}
+			if part.Part_start+nuevoTamanio > limite {
+				return "No hay espacio libre contiguo suficiente después de la partición", true
+			}
+		} else if part.Part_type == 'E' {
+			logicas, er, strError := utils.ObtenerListaEBR(ubicacionArchivo, part)
+			if er {
+				return strError, er
+			}
+
+			minimo := size.SizeEBR()
+			for _, l := range logicas {
+				if l.EBR.Part_s > 0 && l.EBR.Part_start+l.EBR.Part_s-part.Part_start > minimo {
+					minimo = l.EBR.Part_start + l.EBR.Part_s - part.Part_start
+				}
+			}
+			if nuevoTamanio < minimo {
+				return "No se puede reducir la extendida: afectaría particiones lógicas", true
+			}
+		} else if msg, er := reducirSistemaArchivos(file, part.Part_start, nuevoTamanio); er {
+			return msg, true
+		}
+
+		mbr.Mbr_partitions[i].Part_s = nuevoTamanio
+
+		file.Seek(0, 0)
+		if err := binary.Write(file, binary.LittleEndian, &mbr); err != nil {
+			return "Error al escribir el MBR", true
+		}
+
+		actualizarTamanioMontada(ubicacionArchivo, nombreParticion, nuevoTamanio)
+		return fmt.Sprintf("Partición '%s' redimensionada a %d bytes", nombreParticion, nuevoTamanio), false
+	}
+
+	// Lógica: el límite es el siguiente EBR o el fin de la extendida
+	extendida, existe := utils.ObtenerParticionExtendida(mbr)
+	if !existe {
+		return fmt.Sprintf("No existe la partición '%s'", nombreParticion), true
+	}
+
+	logicas, er, strError := utils.ObtenerListaEBR(ubicacionArchivo, extendida)
+	if er {
+		return strError, er
+	}
+
+	for _, l := range logicas {
+		if l.EBR.Part_s <= 0 ||
+			!strings.EqualFold(utils.ConvertirByteAString(l.EBR.Name[:]), nombreParticion) {
+			continue
+		}
+
+		nuevoTamanio := l.EBR.Part_s + cambio
+		if nuevoTamanio <= 0 {
+			return "El tamaño resultante de la partición debe ser mayor a 0", true
+		}
+
+		if cambio > 0 {
+			limite := extendida.Part_start + extendida.Part_s
+			if l.EBR.Part_next != -1 {
+				limite = l.EBR.Part_next
+			}
+			if l.EBR.Part_start+nuevoTamanio > limite {
+				return "No hay espacio libre contiguo suficiente después de la partición", true
+			}
+		} else if msg, er := reducirSistemaArchivos(file, l.EBR.Part_start, nuevoTamanio); er {
+			return msg, true
+		}
+
+		l.EBR.Part_s = nuevoTamanio
+		if er, strError := utils.EscribirEBR(ubicacionArchivo, l.Posicion, l.EBR); er {
+			return strError, er
+		}
+
+		actualizarTamanioMontada(ubicacionArchivo, nombreParticion, nuevoTamanio)
+		return fmt.Sprintf("Partición '%s' redimensionada a %d bytes", nombreParticion, nuevoTamanio), false
+	}
+
+	return fmt.Sprintf("No existe la partición '%s'", nombreParticion), true
+}
+
+// reducirSistemaArchivos valida que una reducción no corte bloques en uso
+// de un EXT2 y ajusta el SuperBloque a la nueva cantidad de bloques.
+func reducirSistemaArchivos(file *os.File, inicio int32, nuevoTamanio int32) (string, bool) {
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(inicio), &sb); err != nil || sb.S_magic != 0xEF53 {
+		// Sin sistema de archivos no hay nada que proteger
+		return "", false
+	}
+
+	bitmap := make([]byte, sb.S_blocks_count)
+	file.Seek(int64(sb.S_bm_block_start), 0)
+	if _, err := file.Read(bitmap); err != nil {
+		return "Error al leer el bitmap de bloques", true
+	}
+
+	ultimoUsado := int32(-1)
+	for i := len(bitmap) - 1; i >= 0; i-- {
+		if bitmap[i] != 0 {
+			ultimoUsado = int32(i)
+			break
+		}
+	}
+
+	nuevoFin := inicio + nuevoTamanio
+	if nuevoFin < sb.S_block_start+(ultimoUsado+1)*sb.S_block_s {
+		return "No se puede reducir la partición: el sistema de archivos usa ese espacio", true
+	}
+
+	bloquesDisponibles := (nuevoFin - sb.S_block_start) / sb.S_block_s
+	if bloquesDisponibles < sb.S_blocks_count {
+		sb.S_free_blocks_count -= sb.S_blocks_count - bloquesDisponibles
+		sb.S_blocks_count = bloquesDisponibles
+
+		file.Seek(int64(inicio), 0)
+		if err := binary.Write(file, binary.LittleEndian, &sb); err != nil {
+			return "Error al actualizar el SuperBloque", true
+		}
+	}
+
+	return "", false
+}
+
+func llenarCeros(file *os.File, inicio int32, tamanio int32) error {
+	if _, err := file.Seek(int64(inicio), 0); err != nil {
+		return err
+	}
+
+	buffer := make([]byte, 1024)
+	restante := tamanio
+
+	for restante > 0 {
+		escribir := int32(len(buffer))
+		if restante < escribir {
+			escribir = restante
+		}
+		if _, err := file.Write(buffer[:escribir]); err != nil {
+			return err
+		}
+		restante -= escribir
+	}
+
+	return nil
+}
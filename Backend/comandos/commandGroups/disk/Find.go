@@ -0,0 +1,132 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func findExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de archivos: find")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	startPath := strings.TrimSpace(props["path"])
+	pattern := strings.TrimSpace(props["name"])
+	if startPath == "" || pattern == "" {
+		return "❌ Error: los parámetros path y name son obligatorios", true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, false)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	startInode, err := resolvePath(ctx.Session, file, sb, 0, startPath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	start, err := ReadInode(file, sb, startInode)
+	if err != nil {
+		return err.Error(), true
+	}
+	if start.I_type != 0 {
+		return fmt.Sprintf("❌ Error: '%s' no es una carpeta", startPath), true
+	}
+	if err := checkPermission(ctx.Session, start, permRead, startPath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	lines := findInTree(ctx.Session, file, sb, start, pattern, 1)
+	if len(lines) == 0 {
+		return fmt.Sprintf("⚠ No hay coincidencias para '%s' en '%s'", pattern, startPath), false
+	}
+
+	return path.Clean("/"+startPath) + "\n" + strings.Join(lines, "\n"), false
+}
+
+// findInTree devuelve las líneas del árbol que llevan a una coincidencia.
+// Las carpetas sin permiso de lectura no se recorren.
+func findInTree(session *Session, file *os.File, sb structures.SuperBlock, dir structures.Inode, pattern string, depth int) []string {
+
+	var lines []string
+	indent := strings.Repeat("  ", depth)
+
+	for _, entry := range directoryEntries(file, sb, dir) {
+		name := strings.TrimRight(string(entry.B_name[:]), "\x00")
+
+		child, err := ReadInode(file, sb, entry.B_inodo)
+		if err != nil {
+			continue
+		}
+
+		var childLines []string
+		label := name
+		if child.I_type == 0 {
+			label += "/"
+			if hasPermission(session, child, permRead) {
+				childLines = findInTree(session, file, sb, child, pattern, depth+1)
+			}
+		}
+
+		if matchWildcard(pattern, name) || len(childLines) > 0 {
+			lines = append(lines, indent+"|_ "+label)
+			lines = append(lines, childLines...)
+		}
+	}
+
+	return lines
+}
+
+// matchWildcard compara name con un patrón donde '*' equivale a cualquier
+// secuencia de caracteres y '?' a exactamente uno
+func matchWildcard(pattern string, name string) bool {
+
+	p, n := 0, 0
+	star, mark := -1, 0
+
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == name[n]):
+			p++
+			n++
+		case p < len(pattern) && pattern[p] == '*':
+			star = p
+			mark = n
+			p++
+		case star != -1:
+			p = star + 1
+			mark++
+			n = mark
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
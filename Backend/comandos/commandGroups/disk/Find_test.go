@@ -0,0 +1,40 @@
+package disk
+
+import "testing"
+
+func TestMatchWildcard(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"a.txt", "a.txt", true},
+		{"a.txt", "b.txt", false},
+		{"*", "", true},
+		{"*", "cualquier.cosa", true},
+		{"?", "", false},
+		{"?", "a", true},
+		{"?", "ab", false},
+		{"*.txt", "notas.txt", true},
+		{"*.txt", ".txt", true},
+		{"*.txt", "notas.txt.bak", false},
+		{"a*", "a", true},
+		{"a*b", "ab", true},
+		{"a*b", "axxb", true},
+		{"a*b", "axxbc", false},
+		{"a*b*c", "abxbxc", true},
+		{"*a*a", "aaa", true},
+		{"?.t?t", "a.txt", true},
+		{"?.t?t", "ab.txt", false},
+		{"**x", "x", true},
+		{"", "", true},
+		{"", "a", false},
+		{"A.txt", "a.txt", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchWildcard(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchWildcard(%q, %q) = %v, se esperaba %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
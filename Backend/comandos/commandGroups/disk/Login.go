@@ -1,29 +1,16 @@
 package disk
 
 import (
-	"encoding/binary"
 	"fmt"
 	"os"
 	"strings"
 
 	"Proyecto/Estructuras/structures"
-
-	"github.com/fatih/color"
 )
 
-type Session struct {
-	User  string
-	Group string
-	Id    string
-	Uid   int32
-	Gid   int32
-}
-
-var currentSession *Session = nil
+func loginExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
 
-func loginExecute(_ string, props map[string]string) (string, bool) {
-
-	if currentSession != nil {
+	if ctx.Session != nil {
 		return "❌ Error: ya existe una sesión activa, debe cerrar sesión primero", true
 	}
 
@@ -48,82 +35,96 @@ func loginExecute(_ string, props map[string]string) (string, bool) {
 		return "❌ Error: el disco asociado a la partición no existe", true
 	}
 
-	file, err := os.Open(part.Path)
+	// La mayoría de inicios de sesión sólo leen users.txt. Si la contraseña
+	// sigue en texto plano se vuelve a validar con el candado de escritura,
+	// porque users.txt pudo cambiar entre ambos accesos.
+	session, legacy, err := authenticate(part, id, user, pass, false)
+	if err == nil && legacy {
+		session, _, err = authenticate(part, id, user, pass, true)
+	}
 	if err != nil {
-		return "❌ Error al abrir el disco", true
+		return err.Error(), true
 	}
-	defer file.Close()
 
-	var sb structures.SuperBlock
-	file.Seek(int64(part.Start), 0)
-	if err := binary.Read(file, binary.LittleEndian, &sb); err != nil {
-		return "❌ Error al leer el SuperBloque", true
+	session, err = startSession(session)
+	if err != nil {
+		return "❌ Error al generar el token de sesión", true
 	}
+	ctx.Session = session
 
-	var usersInode structures.Inode
-	inodePos := sb.S_inode_start + sb.S_inode_s
-	file.Seek(int64(inodePos), 0)
-	if err := binary.Read(file, binary.LittleEndian, &usersInode); err != nil {
-		return "❌ Error al leer el inodo de users.txt", true
-	}
+	return fmt.Sprintf("✅ Sesión iniciada correctamente como %s", user), false
+}
 
-	// Leer todos los bloques
-	var content strings.Builder
-	for _, blk := range usersInode.I_block {
-		if blk == -1 {
-			continue
-		}
-		blockPos := sb.S_block_start + blk*sb.S_block_s
-		buffer := make([]byte, sb.S_block_s)
-		file.Seek(int64(blockPos), 0)
-		file.Read(buffer)
-		content.WriteString(strings.TrimRight(string(buffer), "\x00"))
+// authenticate valida usuario y contraseña contra users.txt y devuelve la
+// sesión sin iniciar. legacy indica que la contraseña está en texto plano;
+// con migrate se reemplaza por su hash y se registra en el journal.
+func authenticate(part *MountedPartition, id string, user string, pass string, migrate bool) (*Session, bool, error) {
+
+	file, release, err := openDisk(part.Path, migrate)
+	if err != nil {
+		return nil, false, fmt.Errorf("❌ Error al abrir el disco")
 	}
+	defer release()
 
-	// DEBUG
-	color.Yellow("----------- DEBUG users.txt -----------")
-	color.White(content.String())
-	color.Yellow("---------------------------------------")
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return nil, false, fmt.Errorf("❌ Error al leer el SuperBloque")
+	}
 
-	lines := strings.Split(strings.TrimSpace(content.String()), "\n")
+	store, err := loadUsersStore(file, sb)
+	if err != nil {
+		return nil, false, err
+	}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+	// findUser y findGroup ignoran los registros eliminados (ID 0)
+	account := store.findUser(user)
+	if account == nil {
+		return nil, false, fmt.Errorf("❌ Error: usuario no existe")
+	}
 
-		fields := strings.Split(line, ",")
-		if len(fields) < 5 {
-			continue
-		}
+	valid, legacy := verifyPassword(account.Password, pass)
+	if !valid {
+		return nil, false, fmt.Errorf("❌ Error: contraseña incorrecta")
+	}
 
-		if fields[1] == "U" && fields[3] == user {
-			if fields[4] != pass {
-				return "❌ Error: contraseña incorrecta", true
-			}
+	group := store.findGroup(account.Group)
+	if group == nil {
+		return nil, false, fmt.Errorf("❌ Error: el grupo '%s' del usuario no existe", account.Group)
+	}
 
-			currentSession = &Session{
-				User:  fields[3],
-				Group: fields[2],
-				Id:    id,
-				Uid:   1,
-				Gid:   1,
-			}
+	session := &Session{
+		User:  account.Name,
+		Group: account.Group,
+		Id:    id,
+		Uid:   account.ID,
+		Gid:   group.ID,
+	}
 
-			return fmt.Sprintf("✅ Sesión iniciada correctamente como %s", user), false
+	// Las contraseñas en texto plano se migran al primer inicio de sesión
+	if legacy && migrate {
+		hash, err := hashPassword(pass)
+		if err != nil {
+			return nil, false, err
+		}
+		account.Password = hash
+		if err := store.save(file, int64(part.Start), &sb); err != nil {
+			return nil, false, err
+		}
+		if err := recordJournal(session, file, int64(part.Start), sb, "passwd", usersFilePath, account.String()); err != nil {
+			return nil, false, err
 		}
 	}
 
-	return "❌ Error: usuario no existe", true
+	return session, legacy, nil
 }
 
 // LOGOUT
-func logoutExecute(_ string, _ map[string]string) (string, bool) {
-	if currentSession == nil {
+func logoutExecute(ctx *CommandContext, _ string, _ map[string]string) (string, bool) {
+	if ctx.Session == nil {
 		return "❌ Error: no hay una sesión activa", true
 	}
 
-	currentSession = nil
+	endSession(ctx.Session)
+	ctx.Session = nil
 	return "✅ Sesión cerrada correctamente", false
 }
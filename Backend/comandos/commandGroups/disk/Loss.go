@@ -0,0 +1,78 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// lossExecute simula la pérdida del sistema de archivos de un EXT3: borra
+// bitmaps, inodos y bloques, pero conserva el SuperBloque y el journal para
+// que recovery pueda reconstruirlo
+func lossExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Simulación de pérdida: loss")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	if ctx.Session.User != "root" {
+		return "❌ Error: solo el usuario root puede simular pérdidas", true
+	}
+
+	id := strings.TrimSpace(props["id"])
+	if id == "" {
+		return "❌ Error: el parámetro id es obligatorio", true
+	}
+
+	if !strings.EqualFold(id, ctx.Session.Id) {
+		return fmt.Sprintf("❌ Error: la sesión activa no pertenece a la partición %s", id), true
+	}
+
+	part := GetMountedPartition(id)
+	if part == nil {
+		return fmt.Sprintf("❌ Error: no existe una partición montada con ID %s", id), true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil || sb.S_magic != 0xEF53 {
+		return "❌ Error: la partición no tiene un sistema de archivos", true
+	}
+	if sb.S_filesystem_type != fsExt3 {
+		return "❌ Error: loss solo aplica a particiones EXT3", true
+	}
+
+	if err := wipeFileSystem(file, sb); err != nil {
+		return err.Error(), true
+	}
+
+	color.Yellow("⚠ Bitmaps, inodos y bloques de %s borrados", part.Id)
+	return fmt.Sprintf("✅ Pérdida simulada en la partición %s", part.Id), false
+}
+
+// wipeFileSystem pone en cero desde el bitmap de inodos hasta el último
+// bloque. La caché de bitmaps del disco se descarta.
+func wipeFileSystem(file *os.File, sb structures.SuperBlock) error {
+
+	dropBitmaps(file.Name())
+
+	end := int64(sb.S_block_start) + int64(sb.S_blocks_count)*int64(sb.S_block_s)
+	zero := make([]byte, end-int64(sb.S_bm_inode_start))
+
+	if _, err := file.WriteAt(zero, int64(sb.S_bm_inode_start)); err != nil {
+		return fmt.Errorf("❌ Error al borrar el sistema de archivos")
+	}
+	return nil
+}
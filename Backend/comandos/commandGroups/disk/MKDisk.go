@@ -10,7 +10,7 @@ import (
 	"github.com/fatih/color"
 )
 
-func mkdiskExecute(comando string, parametros map[string]string) (string, bool) {
+func mkdiskExecute(_ *CommandContext, comando string, parametros map[string]string) (string, bool) {
 
 	tamanio, er, msg := utils.TieneSize(comando, parametros["size"])
 	if er || tamanio <= 0 {
@@ -46,16 +46,21 @@ func mkdisk_Create(_size int32, _unit byte, _fit byte) (string, bool) {
 		nombreDisco := fmt.Sprintf("VDIC-%c.mia", 'A'+i)
 		archivo := utils.DirectorioDisco + nombreDisco
 
-		if _, err := os.Stat(archivo); os.IsNotExist(err) {
-
-			er, strmsg := createDiskFile(archivo, _size, _fit, _unit)
-			if er {
-				return strmsg, true
-			}
+		// El candado evita que dos mkdisk simultáneos elijan la misma letra
+		unlock := LockDisk(archivo)
+		if _, err := os.Stat(archivo); !os.IsNotExist(err) {
+			unlock()
+			continue
+		}
 
-			color.Green("[MKDISK]: Disco %s creado correctamente", nombreDisco)
-			return "", false
+		er, strmsg := createDiskFile(archivo, _size, _fit, _unit)
+		unlock()
+		if er {
+			return strmsg, true
 		}
+
+		color.Green("[MKDISK]: Disco %s creado correctamente", nombreDisco)
+		return "", false
 	}
 
 	return "No hay letras disponibles para crear más discos", true
@@ -3,20 +3,19 @@ package disk
 import (
 	"Proyecto/Estructuras/structures"
 	"fmt"
-	"os"
 	"path"
 	"strings"
 
 	"github.com/fatih/color"
 )
 
-func mkdirExecute(_ string, props map[string]string) (string, bool) {
+func mkdirExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
 
 	color.Green("-----------------------------------------------------------")
 	color.Blue("Administración de carpetas: mkdir")
 	color.Green("-----------------------------------------------------------")
 
-	if currentSession == nil {
+	if ctx.Session == nil {
 		return "❌ Error: no hay una sesión activa", true
 	}
 
@@ -34,22 +33,24 @@ func mkdirExecute(_ string, props map[string]string) (string, bool) {
 		return "❌ Error: el parámetro path es obligatorio", true
 	}
 
-	part := GetMountedPartition(currentSession.Id)
+	part := GetMountedPartition(ctx.Session.Id)
 	if part == nil {
 		return "❌ Error: la partición no está montada", true
 	}
 
-	file, err := os.OpenFile(part.Path, os.O_RDWR, 0666)
+	file, release, err := openDisk(part.Path, true)
 	if err != nil {
 		return "❌ Error al abrir el disco", true
 	}
-	defer file.Close()
+	defer release()
 
 	var sb structures.SuperBlock
 	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
 		return "❌ Error al leer el SuperBloque", true
 	}
 
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
 	cleanPath := path.Clean(dirPath)
 	if cleanPath == "/" {
 		return "❌ Error: no se puede crear la raíz", true
@@ -57,43 +58,22 @@ func mkdirExecute(_ string, props map[string]string) (string, bool) {
 
 	dirs := strings.Split(cleanPath, "/")
 	currentInode := int32(0)
+	currentName := "/"
 
 	for i, dir := range dirs {
 		if dir == "" {
 			continue
 		}
 
-		inode, err := ReadInode(file, sb, currentInode)
-		if err != nil {
+		if err := requireFolder(file, sb, currentInode, currentName); err != nil {
 			return err.Error(), true
 		}
-
-		found := false
-		var nextInode int32
+		if err := checkInodePermission(ctx.Session, file, sb, currentInode, permExec, currentName); err != nil {
+			return "❌ Error: " + err.Error(), true
+		}
 
 		// Buscar carpeta existente
-		for _, blk := range inode.I_block {
-			if blk == -1 {
-				continue
-			}
-
-			var block structures.BloqueCarpeta
-			if err := ReadBlock(file, sb, blk, &block); err != nil {
-				return err.Error(), true
-			}
-
-			for _, content := range block.B_content {
-				name := strings.TrimRight(string(content.B_name[:]), "\x00")
-				if name == dir {
-					nextInode = content.B_inodo
-					found = true
-					break
-				}
-			}
-			if found {
-				break
-			}
-		}
+		found, nextInode := findEntryInDirectory(file, sb, currentInode, dir)
 
 		isLast := i == len(dirs)-1
 
@@ -104,7 +84,11 @@ func mkdirExecute(_ string, props map[string]string) (string, bool) {
 				return fmt.Sprintf("❌ Error: la carpeta '%s' no existe", dir), true
 			}
 
-			newInode, err := createDirectory(file, sb, currentInode, dir)
+			if err := checkInodePermission(ctx.Session, file, sb, currentInode, permWrite, currentName); err != nil {
+				return "❌ Error: " + err.Error(), true
+			}
+
+			newInode, err := createDirectory(ctx.Session, file, sb, currentInode, dir)
 			if err != nil {
 				return err.Error(), true
 			}
@@ -112,6 +96,11 @@ func mkdirExecute(_ string, props map[string]string) (string, bool) {
 		}
 
 		currentInode = nextInode
+		currentName = dir
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "mkdir", path.Clean("/"+dirPath), ""); err != nil {
+		return err.Error(), true
 	}
 
 	return fmt.Sprintf("✅ Carpeta '%s' creada correctamente", dirPath), false
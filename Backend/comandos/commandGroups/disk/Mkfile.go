@@ -11,13 +11,13 @@ import (
 	"github.com/fatih/color"
 )
 
-func mkfileExecute(_ string, props map[string]string) (string, bool) {
+func mkfileExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
 
 	color.Green("-----------------------------------------------------------")
 	color.Blue("Administración de archivos: mkfile")
 	color.Green("-----------------------------------------------------------")
 
-	if currentSession == nil {
+	if ctx.Session == nil {
 		return "❌ Error: no hay una sesión activa", true
 	}
 
@@ -42,49 +42,97 @@ func mkfileExecute(_ string, props map[string]string) (string, bool) {
 		size = int32(s)
 	}
 
-	part := GetMountedPartition(currentSession.Id)
+	// -cont tiene prioridad sobre -size
+	content := sizeContent(size)
+	if contPath := strings.TrimSpace(props["cont"]); contPath != "" {
+		data, err := readHostFile(contPath)
+		if err != nil {
+			return err.Error(), true
+		}
+		content = data
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
 	if part == nil {
 		return "❌ Error: la partición no está montada", true
 	}
 
-	file, err := os.OpenFile(part.Path, os.O_RDWR, 0666)
+	file, release, err := openDisk(part.Path, true)
 	if err != nil {
 		return "❌ Error al abrir el disco", true
 	}
-	defer file.Close()
+	defer release()
 
 	var sb structures.SuperBlock
 	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
 		return err.Error(), true
 	}
 
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
 	cleanPath := path.Clean(filePath)
 	parentPath := path.Dir(cleanPath)
 	fileName := path.Base(cleanPath)
 
-	parentInode, err := traversePath(file, sb, parentPath, rFlag)
+	if fileName == "/" || fileName == "." || fileName == ".." {
+		return "❌ Error: nombre de archivo inválido", true
+	}
+
+	parentInode, err := traversePath(ctx.Session, file, sb, parentPath, rFlag)
 	if err != nil {
 		return err.Error(), true
 	}
 
 	exists, inodeIndex := findEntryInDirectory(file, sb, parentInode, fileName)
 	if exists {
-		color.Yellow("⚠ El archivo ya existe, será sobrescrito")
-		cleanFileBlocks(file, sb, inodeIndex)
-		writeFileContentSafe(file, sb, inodeIndex, size)
+		if err := checkInodePermission(ctx.Session, file, sb, inodeIndex, permWrite, cleanPath); err != nil {
+			return "❌ Error: " + err.Error(), true
+		}
+		if err := overwriteFile(file, sb, inodeIndex, content, cleanPath); err != nil {
+			return err.Error(), true
+		}
+		color.Yellow("⚠ El archivo ya existía y fue sobrescrito")
+		if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "mkfile", path.Clean("/"+filePath), string(content)); err != nil {
+			return err.Error(), true
+		}
 		return fmt.Sprintf("✅ Archivo '%s' sobrescrito correctamente", filePath), false
 	}
 
-	inodeIndex = FindFreeInode(file, sb)
+	if err := checkInodePermission(ctx.Session, file, sb, parentInode, permWrite, parentPath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	if _, err := createFile(ctx.Session, file, sb, parentInode, fileName, content); err != nil {
+		return err.Error(), true
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "mkfile", path.Clean("/"+filePath), string(content)); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ Archivo '%s' creado correctamente", filePath), false
+}
+
+// createFile crea el archivo name en parent con el contenido indicado
+func createFile(
+	session *Session,
+	file *os.File,
+	sb structures.SuperBlock,
+	parent int32,
+	name string,
+	content []byte,
+) (int32, error) {
+
+	inodeIndex := FindFreeInode(file, sb)
 	if inodeIndex == -1 {
-		return "❌ Error: no hay inodos libres", true
+		return -1, fmt.Errorf("❌ Error: no hay inodos libres")
 	}
 
 	now := int32(time.Now().Unix())
 
 	inode := structures.Inode{
-		I_uid:   currentSession.Uid,
-		I_gid:   currentSession.Gid,
+		I_uid:   session.Uid,
+		I_gid:   session.Gid,
 		I_s:     0,
 		I_atime: now,
 		I_ctime: now,
@@ -100,13 +148,35 @@ func mkfileExecute(_ string, props map[string]string) (string, bool) {
 	WriteInode(file, sb, inodeIndex, inode)
 	MarkBitmap(file, sb.S_bm_inode_start, inodeIndex)
 
-	writeFileContentSafe(file, sb, inodeIndex, size)
+	// Si falta espacio writeFileContentSafe ya liberó sus bloques; sólo
+	// queda liberar el inodo
+	if err := writeFileContentSafe(file, sb, inodeIndex, content); err != nil {
+		UnmarkBitmap(file, sb.S_bm_inode_start, inodeIndex)
+		return -1, err
+	}
 
-	if err := addEntryToDirectory(file, sb, parentInode, fileName, inodeIndex); err != nil {
-		return err.Error(), true
+	if err := addEntryToDirectory(file, sb, parent, name, inodeIndex); err != nil {
+		cleanFileBlocks(file, sb, inodeIndex)
+		UnmarkBitmap(file, sb.S_bm_inode_start, inodeIndex)
+		return -1, err
 	}
 
-	return fmt.Sprintf("✅ Archivo '%s' creado correctamente", filePath), false
+	return inodeIndex, nil
+}
+
+// overwriteFile reemplaza el contenido de un archivo existente. Una carpeta
+// se rechaza antes de tocar sus bloques.
+func overwriteFile(file *os.File, sb structures.SuperBlock, inodeIndex int32, content []byte, p string) error {
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err
+	}
+	if inode.I_type != 1 {
+		return fmt.Errorf("❌ Error: '%s' es una carpeta, no un archivo", p)
+	}
+
+	return writeFileContentSafe(file, sb, inodeIndex, content)
 }
 
 // LIMPIAR BLOQUES
@@ -116,50 +186,82 @@ func cleanFileBlocks(file *os.File, sb structures.SuperBlock, inodeIndex int32)
 		return
 	}
 
-	for i, blk := range inode.I_block {
-		if blk != -1 {
-			UnmarkBitmap(file, sb.S_bm_block_start, blk)
-			inode.I_block[i] = -1
-		}
-	}
+	freeInodeBlocks(file, sb, &inode)
 
 	inode.I_s = 0
 	WriteInode(file, sb, inodeIndex, inode)
 }
 
-func writeFileContentSafe(file *os.File, sb structures.SuperBlock, inodeIndex int32, size int32) {
+// writeFileContentSafe escribe el contenido en bloques nuevos y sólo al
+// terminar libera los anteriores: si falta espacio el archivo queda como
+// estaba
+func writeFileContentSafe(file *os.File, sb structures.SuperBlock, inodeIndex int32, content []byte) error {
 
 	inode, err := ReadInode(file, sb, inodeIndex)
-	if err != nil || inode.I_type != 1 {
-		return
+	if err != nil {
+		return err
 	}
+	if inode.I_type != 1 {
+		return fmt.Errorf("❌ Error: el inodo %d no es un archivo", inodeIndex)
+	}
+
+	blockSize := int32(len(structures.BloqueArchivo{}.B_content))
+	needed := (int32(len(content)) + blockSize - 1) / blockSize
 
-	var written int32 = 0
+	if needed > maxInodeBlocks {
+		return fmt.Errorf("❌ Error: se excede la capacidad máxima del inodo")
+	}
 
-	for i := 0; i < 15 && written < size; i++ {
+	staged := inode
+	for i := range staged.I_block {
+		staged.I_block[i] = -1
+	}
 
-		if inode.I_block[i] == -1 {
-			blk := FindFreeBlock(file, sb)
-			if blk == -1 {
-				break
-			}
-			inode.I_block[i] = blk
-			MarkBitmap(file, sb.S_bm_block_start, blk)
+	for n := int32(0); n < needed; n++ {
+		blk, err := inodeBlockAt(file, sb, &staged, n, true)
+		if err == nil {
+			var fb structures.BloqueArchivo
+			copy(fb.B_content[:], content[n*blockSize:])
+			err = WriteBlock(file, sb, blk, &fb)
+		}
+		if err != nil {
+			freeInodeBlocks(file, sb, &staged)
+			return err
 		}
+	}
 
-		var block structures.BloqueArchivo
+	freeInodeBlocks(file, sb, &inode)
 
-		for j := 0; j < 64 && written < size; j++ {
-			block.B_content[j] = byte('0' + (written % 10))
-			written++
-		}
+	staged.I_s = int32(len(content))
+	staged.I_mtime = int32(time.Now().Unix())
+	return WriteInode(file, sb, inodeIndex, staged)
+}
 
-		WriteBlock(file, sb, inode.I_block[i], &block)
+// sizeContent genera el contenido por defecto: 0123456789 repetido
+func sizeContent(size int32) []byte {
+	content := make([]byte, size)
+	for i := range content {
+		content[i] = byte('0' + (i % 10))
+	}
+	return content
+}
 
-		fmt.Printf("DEBUG MKFILE: bloque=%d escritos=%d\n", inode.I_block[i], written)
+// readHostFile lee un archivo del sistema donde corre el servidor
+func readHostFile(hostPath string) ([]byte, error) {
+	info, err := os.Stat(hostPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("❌ Error: el archivo '%s' no existe en el sistema", hostPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ Error: no se pudo acceder al archivo '%s'", hostPath)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("❌ Error: '%s' es una carpeta, no un archivo", hostPath)
 	}
 
-	inode.I_s = size
-	inode.I_mtime = int32(time.Now().Unix())
-	WriteInode(file, sb, inodeIndex, inode)
+	data, err := os.ReadFile(hostPath)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Error: no se pudo leer el archivo '%s'", hostPath)
+	}
+	return data, nil
 }
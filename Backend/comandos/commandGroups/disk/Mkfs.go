@@ -12,45 +12,62 @@ import (
 type MKFS struct {
 	Id   string
 	Type string
+	Fs   string // 2fs = EXT2, 3fs = EXT3
 }
 
-func (mkfs *MKFS) Execute() {
+// Execute formatea la partición montada. Los parámetros y el espacio se
+// validan antes de tocar el disco.
+func (mkfs *MKFS) Execute() error {
 
 	if mkfs.Type != "" && mkfs.Type != "full" && mkfs.Type != "fast" {
-		fmt.Println("❌ Error: tipo de formato no válido")
-		return
+		return fmt.Errorf("❌ Error: tipo de formato no válido")
 	}
 
-	part := GetMountedPartition(mkfs.Id)
-	if part == nil {
-		fmt.Println("❌ Error: No existe una partición montada con ese ID")
-		return
+	fsType := fsExt2
+	switch mkfs.Fs {
+	case "", "2fs":
+	case "3fs":
+		fsType = fsExt3
+	default:
+		return fmt.Errorf("❌ Error: sistema de archivos no válido (2fs | 3fs)")
 	}
 
-	file, err := os.OpenFile(part.Path, os.O_RDWR, 0666)
-	if err != nil {
-		fmt.Println("❌ Error al abrir el disco")
-		return
+	part := GetMountedPartition(mkfs.Id)
+	if part == nil {
+		return fmt.Errorf("❌ Error: No existe una partición montada con ese ID")
 	}
-	defer file.Close()
-
-	file.Seek(int64(part.Start), 0)
-	zero := make([]byte, part.Size)
-	file.Write(zero)
 
 	size := part.Size
-	sbSize := int32(binary.Size(structures.SuperBlock{}))
 	inodeSize := int32(binary.Size(structures.Inode{}))
 	blockSize := int32(64)
 
-	n := (size - sbSize) / (inodeSize + 3*blockSize)
-	if n <= 0 {
-		fmt.Println("❌ Error: espacio insuficiente para EXT2")
-		return
+	// Por cada inodo: su byte de bitmap, 3 bloques con sus 3 bytes de bitmap
+	// y, en EXT3, una entrada de journal
+	journal := int32(0)
+	if fsType == fsExt3 {
+		journal = journalSize
+	}
+
+	n := (size - superBlockSize) / (journal + 1 + 3 + inodeSize + 3*blockSize)
+	if n <= 2 {
+		return fmt.Errorf("❌ Error: espacio insuficiente para EXT%d", fsType)
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return fmt.Errorf("❌ Error al abrir el disco")
+	}
+	defer release()
+
+	// Los bitmaps anteriores dejan de ser válidos
+	dropBitmaps(part.Path)
+
+	if _, err := file.WriteAt(make([]byte, part.Size), int64(part.Start)); err != nil {
+		return fmt.Errorf("❌ Error al limpiar la partición")
 	}
 
 	sb := structures.SuperBlock{
-		S_filesystem_type:   2,
+		S_filesystem_type:   fsType,
 		S_inodes_count:      n,
 		S_blocks_count:      n * 3,
 		S_free_inodes_count: n - 2, // root y users.txt ocupan 2
@@ -65,15 +82,13 @@ func (mkfs *MKFS) Execute() {
 		S_first_blo:         2,
 	}
 
-	sb.S_bm_inode_start = part.Start + sbSize
+	sb.S_bm_inode_start = part.Start + superBlockSize + n*journal
 	sb.S_bm_block_start = sb.S_bm_inode_start + n
 	sb.S_inode_start = sb.S_bm_block_start + (n * 3)
 	sb.S_block_start = sb.S_inode_start + (n * inodeSize)
 
-	file.Seek(int64(part.Start), 0)
-	if err := binary.Write(file, binary.LittleEndian, &sb); err != nil {
-		fmt.Println("❌ Error al escribir el SuperBloque")
-		return
+	if err := WriteSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return fmt.Errorf("❌ Error al escribir el SuperBloque")
 	}
 
 	initBitmap(file, sb.S_bm_inode_start, n)
@@ -81,14 +96,23 @@ func (mkfs *MKFS) Execute() {
 
 	createRootAndUsers(file, sb)
 
-	fmt.Println("✅ MKFS realizado correctamente en EXT2")
+	if err := appendJournal(file, int64(part.Start), sb, JournalEntry{
+		Operation: "mkfs",
+		Path:      "/",
+		Content:   mkfs.Fs,
+		Date:      sb.S_mtime,
+		Uid:       1,
+		Gid:       1,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ MKFS realizado correctamente en EXT%d\n", fsType)
+	return nil
 }
 
 func initBitmap(file *os.File, start int32, size int32) {
-	file.Seek(int64(start), 0)
-	for i := int32(0); i < size; i++ {
-		file.Write([]byte{0})
-	}
+	file.WriteAt(make([]byte, size), int64(start))
 }
 
 func markBitmap(file *os.File, start int32, index int32) {
@@ -101,9 +125,10 @@ func createRootAndUsers(file *os.File, sb structures.SuperBlock) {
 	now := int32(time.Now().Unix())
 
 	// ---- INODO RAÍZ ----
+	// Propietario: usuario root (ID 1) y grupo root (ID 1) de users.txt
 	root := structures.Inode{
-		I_uid:   0,
-		I_gid:   0,
+		I_uid:   1,
+		I_gid:   1,
 		I_s:     sb.S_block_s,
 		I_atime: now,
 		I_ctime: now,
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 )
@@ -20,7 +21,12 @@ type MountedPartition struct {
 	Size     int32
 }
 
-var mountedPartitions []MountedPartition
+// mountedMu protege mountedPartitions; las funciones que no lo toman
+// asumen que el llamador ya lo tiene
+var (
+	mountedMu         sync.RWMutex
+	mountedPartitions []MountedPartition
+)
 
 // obtiene la letra del disco a partir del nombre (VDIC-A.mia -> A)
 func obtenerLetraDisco(diskName string) byte {
@@ -34,6 +40,10 @@ func obtenerLetraDisco(diskName string) byte {
 	return 'A'
 }
 
+func generarIdMontaje(correlativo int32, diskName string) string {
+	return fmt.Sprintf("21%d%c", correlativo, obtenerLetraDisco(diskName))
+}
+
 func obtenerCorrelativoGlobal() int32 {
 	var max int32 = 0
 	for _, part := range mountedPartitions {
@@ -49,7 +59,7 @@ func obtenerCorrelativoGlobal() int32 {
 	return max + 1
 }
 
-func mountExecute(_ string, props map[string]string) (string, bool) {
+func mountExecute(_ *CommandContext, _ string, props map[string]string) (string, bool) {
 
 	diskName := strings.TrimSpace(props["diskname"])
 	partName := strings.TrimSpace(props["name"])
@@ -64,6 +74,8 @@ func mountExecute(_ string, props map[string]string) (string, bool) {
 
 	path := utils.DirectorioDisco + diskName
 
+	defer LockDisk(path)()
+
 	file, err := os.Open(path)
 	if err != nil {
 		return fmt.Sprintf("No se pudo abrir el disco: %s", diskName), true
@@ -75,45 +87,71 @@ func mountExecute(_ string, props map[string]string) (string, bool) {
 		return "Error al leer el MBR", true
 	}
 
-	partIndex := -1
+	found := false
+	var start, partSize int32
+
 	for i := 0; i < 4; i++ {
 		part := mbr.Mbr_partitions[i]
+		if part.Part_start == -1 {
+			continue
+		}
+
 		name := utils.ConvertirByteAString(part.Part_name[:])
 
 		if strings.EqualFold(name, partName) {
-			if part.Part_type != 'P' {
-				return "Solo se pueden montar particiones primarias", true
+			if part.Part_type == 'E' {
+				return "No se puede montar una partición extendida", true
 			}
-			partIndex = i
+			start, partSize = part.Part_start, part.Part_s
+			found = true
 			break
 		}
 	}
 
-	if partIndex == -1 {
-		return fmt.Sprintf("No existe la partición '%s'", partName), true
-	}
+	// Buscar entre las particiones lógicas
+	if extendida, existe := utils.ObtenerParticionExtendida(mbr); !found && existe {
+		logicas, er, msg := utils.ObtenerListaEBR(path, extendida)
+		if er {
+			return msg, true
+		}
 
-	for _, mp := range mountedPartitions {
-		if strings.EqualFold(mp.Path, path) &&
-			strings.EqualFold(mp.Name, partName) {
-			return "La partición ya se encuentra montada", true
+		for _, l := range logicas {
+			if l.EBR.Part_s > 0 && strings.EqualFold(utils.ConvertirByteAString(l.EBR.Name[:]), partName) {
+				start, partSize = l.EBR.Part_start, l.EBR.Part_s
+				found = true
+				break
+			}
 		}
 	}
 
-	part := mbr.Mbr_partitions[partIndex]
+	if !found {
+		return fmt.Sprintf("No existe la partición '%s'", partName), true
+	}
+
+	mountedMu.Lock()
+	defer mountedMu.Unlock()
+
+	if estaMontada(path, partName) {
+		return "La partición ya se encuentra montada", true
+	}
 
 	// Generar ID
 	correlativo := obtenerCorrelativoGlobal()
-	letra := obtenerLetraDisco(diskName)
-	id := fmt.Sprintf("21%d%c", correlativo, letra)
+	id := generarIdMontaje(correlativo, diskName)
+
+	file.Close()
+	if msg, er := marcarMontaje(path, partName, correlativo, true); er {
+		return msg, true
+	}
+	actualizarSuperBloqueMontaje(path, start, true)
 
 	mountedPartitions = append(mountedPartitions, MountedPartition{
 		Id:       id,
 		DiskName: diskName,
 		Path:     path,
 		Name:     partName,
-		Start:    part.Part_start,
-		Size:     part.Part_s,
+		Start:    start,
+		Size:     partSize,
 	})
 
 	color.Green("-----------------------------------------------------------")
@@ -126,16 +164,221 @@ func mountExecute(_ string, props map[string]string) (string, bool) {
 	return fmt.Sprintf("Partición montada correctamente con ID %s", id), false
 }
 
+// estaMontada indica si la partición del disco está en mountedPartitions
+func estaMontada(path string, name string) bool {
+	for _, mp := range mountedPartitions {
+		if strings.EqualFold(mp.Path, path) && strings.EqualFold(mp.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// actualizarTamanioMontada refleja un cambio de tamaño en la partición montada
+func actualizarTamanioMontada(path string, name string, size int32) {
+	mountedMu.Lock()
+	defer mountedMu.Unlock()
+
+	for i := range mountedPartitions {
+		if strings.EqualFold(mountedPartitions[i].Path, path) &&
+			strings.EqualFold(mountedPartitions[i].Name, name) {
+			mountedPartitions[i].Size = size
+		}
+	}
+}
+
+// GetMountedPartition devuelve una copia de la partición montada con ese ID
 func GetMountedPartition(id string) *MountedPartition {
+	mountedMu.RLock()
+	defer mountedMu.RUnlock()
+
+	index := indiceMontada(id)
+	if index == -1 {
+		return nil
+	}
+
+	part := mountedPartitions[index]
+	return &part
+}
+
+func indiceMontada(id string) int {
 	id = strings.TrimSpace(id)
 	if id == "" {
-		return nil
+		return -1
 	}
 
 	for i := range mountedPartitions {
 		if strings.EqualFold(mountedPartitions[i].Id, id) {
-			return &mountedPartitions[i]
+			return i
 		}
 	}
-	return nil
+	return -1
+}
+
+// ListMountedPartitions devuelve una copia de las particiones montadas
+func ListMountedPartitions() []MountedPartition {
+	mountedMu.RLock()
+	defer mountedMu.RUnlock()
+
+	return append([]MountedPartition{}, mountedPartitions...)
+}
+
+// marcarMontaje guarda el estado de montaje y el correlativo en el MBR (o en
+// el EBR si es lógica) para poder reconstruir la tabla al reiniciar el
+// servidor. El ID no se guarda: con correlativos de dos cifras no cabe en
+// Part_id, así que siempre se deriva con generarIdMontaje.
+func marcarMontaje(path string, name string, correlativo int32, montada bool) (string, bool) {
+
+	mbr, er, msg := utils.ObtenerEstructuraMBR(path)
+	if er {
+		return msg, true
+	}
+
+	var estado int8 = 0
+	if montada {
+		estado = 1
+	}
+
+	for i := range mbr.Mbr_partitions {
+		part := &mbr.Mbr_partitions[i]
+		if part.Part_start == -1 || part.Part_type == 'E' ||
+			!strings.EqualFold(utils.ConvertirByteAString(part.Part_name[:]), name) {
+			continue
+		}
+
+		part.Part_status = estado
+		if montada {
+			part.Part_correlative = correlativo
+		}
+
+		if er, msg := utils.EscribirEstructuraMBR(path, mbr); er {
+			return msg, true
+		}
+		return "", false
+	}
+
+	extendida, existe := utils.ObtenerParticionExtendida(mbr)
+	if !existe {
+		return fmt.Sprintf("No existe la partición '%s'", name), true
+	}
+
+	logicas, er, msg := utils.ObtenerListaEBR(path, extendida)
+	if er {
+		return msg, true
+	}
+
+	for _, l := range logicas {
+		if l.EBR.Part_s <= 0 || !strings.EqualFold(utils.ConvertirByteAString(l.EBR.Name[:]), name) {
+			continue
+		}
+
+		l.EBR.Part_mount = estado
+		if montada {
+			l.EBR.Part_correlative = correlativo
+		}
+
+		if er, msg := utils.EscribirEBR(path, l.Posicion, l.EBR); er {
+			return msg, true
+		}
+		return "", false
+	}
+
+	return fmt.Sprintf("No existe la partición '%s'", name), true
+}
+
+// actualizarSuperBloqueMontaje registra el montaje/desmontaje en el
+// SuperBloque cuando la partición ya tiene un sistema de archivos.
+func actualizarSuperBloqueMontaje(path string, start int32, montada bool) {
+
+	file, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(start), &sb); err != nil || sb.S_magic != 0xEF53 {
+		return
+	}
+
+	if montada {
+		sb.S_mtime = utils.ObFechaInt()
+		sb.S_mnt_count++
+	} else {
+		sb.S_umtime = utils.ObFechaInt()
+	}
+
+	file.Seek(int64(start), 0)
+	binary.Write(file, binary.LittleEndian, &sb)
+}
+
+// CargarParticionesMontadas reconstruye mountedPartitions a partir del
+// estado de montaje guardado en los discos existentes.
+func CargarParticionesMontadas() {
+
+	entries, err := os.ReadDir(utils.DirectorioDisco)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		diskName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(strings.ToLower(diskName), ".mia") {
+			continue
+		}
+
+		path := utils.DirectorioDisco + diskName
+		mbr, er, _ := utils.ObtenerEstructuraMBR(path)
+		if er {
+			continue
+		}
+
+		for _, part := range mbr.Mbr_partitions {
+			if part.Part_start != -1 && part.Part_type != 'E' && part.Part_status == 1 {
+				restaurarMontaje(diskName, path, utils.ConvertirByteAString(part.Part_name[:]),
+					part.Part_correlative, part.Part_start, part.Part_s)
+			}
+		}
+
+		extendida, existe := utils.ObtenerParticionExtendida(mbr)
+		if !existe {
+			continue
+		}
+
+		logicas, er, _ := utils.ObtenerListaEBR(path, extendida)
+		if er {
+			continue
+		}
+
+		for _, l := range logicas {
+			if l.EBR.Part_s > 0 && l.EBR.Part_mount == 1 {
+				restaurarMontaje(diskName, path, utils.ConvertirByteAString(l.EBR.Name[:]),
+					l.EBR.Part_correlative, l.EBR.Part_start, l.EBR.Part_s)
+			}
+		}
+	}
+}
+
+func restaurarMontaje(diskName string, path string, name string, correlativo int32, start int32, size int32) {
+
+	id := generarIdMontaje(correlativo, diskName)
+
+	mountedMu.Lock()
+	defer mountedMu.Unlock()
+
+	if correlativo <= 0 || indiceMontada(id) != -1 {
+		color.Yellow("[MOUNT]: No se pudo restaurar el montaje de %s en %s", name, diskName)
+		return
+	}
+
+	mountedPartitions = append(mountedPartitions, MountedPartition{
+		Id:       id,
+		DiskName: diskName,
+		Path:     path,
+		Name:     name,
+		Start:    start,
+		Size:     size,
+	})
+
+	color.Cyan("[MOUNT]: Partición %s restaurada con ID %s", name, id)
 }
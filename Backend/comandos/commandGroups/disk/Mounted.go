@@ -7,21 +7,23 @@ import (
 )
 
 // mountedExecute muestra todas las particiones montadas
-func mountedExecute(_ string, _ map[string]string) (string, bool) {
+func mountedExecute(_ *CommandContext, _ string, _ map[string]string) (string, bool) {
 
 	color.Green("-----------------------------------------------------------")
 	color.Blue("Particiones montadas en el sistema")
 	color.Green("-----------------------------------------------------------")
 
-	if len(mountedPartitions) == 0 {
+	montadas := ListMountedPartitions()
+
+	if len(montadas) == 0 {
 		color.Yellow("No hay particiones montadas actualmente")
 		return "No hay particiones montadas", false
 	}
 
-	for _, part := range mountedPartitions {
+	for _, part := range montadas {
 		color.Cyan("• %s", part.Id)
 	}
 
 	color.Green("-----------------------------------------------------------")
-	return fmt.Sprintf("Total de particiones montadas: %d", len(mountedPartitions)), false
+	return fmt.Sprintf("Total de particiones montadas: %d", len(montadas)), false
 }
@@ -0,0 +1,159 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func moveExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de archivos: move")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	sourcePath := strings.TrimSpace(props["path"])
+	destPath := strings.TrimSpace(props["destino"])
+	if sourcePath == "" || destPath == "" {
+		return "❌ Error: los parámetros path y destino son obligatorios", true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
+	cleanPath := path.Clean("/" + sourcePath)
+	if cleanPath == "/" {
+		return "❌ Error: no se puede mover la raíz", true
+	}
+
+	parentInode, err := resolvePath(ctx.Session, file, sb, 0, path.Dir(cleanPath))
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	name := path.Base(cleanPath)
+	found, inodeIndex := findEntryInDirectory(file, sb, parentInode, name)
+	if !found {
+		return fmt.Sprintf("❌ Error: no existe '%s'", sourcePath), true
+	}
+
+	if inodeIndex == usersInodeIndex {
+		return "❌ Error: users.txt no puede moverse", true
+	}
+
+	destInode, err := resolvePath(ctx.Session, file, sb, 0, destPath)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	dest, err := ReadInode(file, sb, destInode)
+	if err != nil {
+		return err.Error(), true
+	}
+	if dest.I_type != 0 {
+		return fmt.Sprintf("❌ Error: '%s' no es una carpeta", destPath), true
+	}
+
+	if destInode == parentInode {
+		return fmt.Sprintf("✅ '%s' ya se encuentra en '%s'", sourcePath, destPath), false
+	}
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if inode.I_type == 0 && isSameOrDescendant(file, sb, inodeIndex, destInode) {
+		return "❌ Error: no se puede mover una carpeta dentro de sí misma", true
+	}
+
+	if exists, _ := findEntryInDirectory(file, sb, destInode, name); exists {
+		return fmt.Sprintf("❌ Error: ya existe '%s' en '%s'", name, destPath), true
+	}
+
+	parent, err := ReadInode(file, sb, parentInode)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if err := checkPermission(ctx.Session, inode, permWrite, sourcePath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+	if err := checkPermission(ctx.Session, parent, permWrite, path.Dir(cleanPath)); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+	if err := checkPermission(ctx.Session, dest, permWrite, destPath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	// Se enlaza primero en el destino para no perder la entrada si falla
+	if err := addEntryToDirectory(file, sb, destInode, name, inodeIndex); err != nil {
+		return err.Error(), true
+	}
+
+	if err := removeEntryFromDirectory(file, sb, parentInode, name); err != nil {
+		return err.Error(), true
+	}
+
+	if inode.I_type == 0 {
+		if err := setParentEntry(file, sb, inodeIndex, destInode); err != nil {
+			return err.Error(), true
+		}
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "move", cleanPath, path.Clean("/"+destPath)); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ '%s' movido a '%s'", sourcePath, destPath), false
+}
+
+// setParentEntry apunta la entrada ".." de la carpeta al nuevo padre
+func setParentEntry(file *os.File, sb structures.SuperBlock, dirInode int32, parentInode int32) error {
+
+	dir, err := ReadInode(file, sb, dirInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blk := range inodeDataBlocks(file, sb, dir) {
+		var folder structures.BloqueCarpeta
+		if err := ReadBlock(file, sb, blk, &folder); err != nil {
+			return err
+		}
+
+		for i := range folder.B_content {
+			entryName := strings.TrimRight(string(folder.B_content[i].B_name[:]), "\x00")
+			if folder.B_content[i].B_inodo != -1 && entryName == ".." {
+				folder.B_content[i].B_inodo = parentInode
+				return WriteBlock(file, sb, blk, &folder)
+			}
+		}
+	}
+
+	// Carpetas antiguas sin ".." no requieren ajuste
+	return nil
+}
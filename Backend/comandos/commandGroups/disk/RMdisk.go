@@ -11,16 +11,12 @@ import (
 	"github.com/fatih/color"
 )
 
-func rmdiskExecute(_ string, props map[string]string) (string, bool) {
+func rmdiskExecute(_ *CommandContext, _ string, props map[string]string) (string, bool) {
 
 	color.Green("-----------------------------------------------------------")
 	color.Blue("Administración de discos: rmdisk")
 	color.Green("-----------------------------------------------------------")
 
-	if currentSession != nil {
-		return "❌ Error: no se puede eliminar un disco con una sesión activa", true
-	}
-
 	diskName := strings.TrimSpace(props["diskname"])
 	if diskName == "" {
 		return "❌ Error: el parámetro diskName es obligatorio", true
@@ -32,13 +28,20 @@ func rmdiskExecute(_ string, props map[string]string) (string, bool) {
 
 	diskPath := filepath.Join(utils.DirectorioDisco, diskName)
 
+	defer LockDisk(diskPath)()
+
 	if _, err := os.Stat(diskPath); os.IsNotExist(err) {
 		return fmt.Sprintf("❌ Error: el disco '%s' no existe", diskName), true
 	}
 
+	if sessionOnPartition(diskPath, "") {
+		return "❌ Error: no se puede eliminar un disco con una sesión activa", true
+	}
+
 	if err := os.Remove(diskPath); err != nil {
 		return fmt.Sprintf("❌ Error al eliminar el disco '%s'", diskName), true
 	}
+	dropBitmaps(diskPath)
 
 	color.Green("🗑 Disco eliminado correctamente: %s", diskPath)
 	return fmt.Sprintf("✅ Disco '%s' eliminado correctamente", diskName), false
@@ -0,0 +1,275 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// recoveryExecute reconstruye un EXT3 a partir de su journal: formatea de
+// nuevo bitmaps, inodos y bloques (como mkfs) y repite cada operación con el
+// usuario y grupo que la ejecutaron, así que dueños y permisos quedan igual.
+func recoveryExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Recuperación del sistema de archivos: recovery")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	if ctx.Session.User != "root" {
+		return "❌ Error: solo el usuario root puede recuperar el sistema de archivos", true
+	}
+
+	id := strings.TrimSpace(props["id"])
+	if id == "" {
+		return "❌ Error: el parámetro id es obligatorio", true
+	}
+
+	if !strings.EqualFold(id, ctx.Session.Id) {
+		return fmt.Sprintf("❌ Error: la sesión activa no pertenece a la partición %s", id), true
+	}
+
+	part := GetMountedPartition(id)
+	if part == nil {
+		return fmt.Sprintf("❌ Error: no existe una partición montada con ID %s", id), true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil || sb.S_magic != 0xEF53 {
+		return "❌ Error: la partición no tiene un sistema de archivos", true
+	}
+	if sb.S_filesystem_type != fsExt3 {
+		return "❌ Error: recovery solo aplica a particiones EXT3", true
+	}
+
+	entries, err := ReadJournal(file, int64(part.Start), sb)
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	if err := wipeFileSystem(file, sb); err != nil {
+		return err.Error(), true
+	}
+	createRootAndUsers(file, sb)
+	refreshFreeCounts(file, &sb)
+
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
+	failed := 0
+	for i, entry := range entries {
+		if err := replayJournalEntry(replaySession(file, sb, entry), file, sb, entry); err != nil {
+			failed++
+			color.Yellow("⚠ Operación %d (%s %s) no aplicada: %s", i+1, entry.Operation, entry.Path, err.Error())
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Sprintf("⚠ Partición %s recuperada con %d de %d operaciones sin aplicar", part.Id, failed, len(entries)), false
+	}
+	return fmt.Sprintf("✅ Partición %s recuperada (%d operaciones aplicadas)", part.Id, len(entries)), false
+}
+
+// replaySession arma la sesión con que se ejecutó la operación. Los nombres
+// se buscan en users.txt tal como está en ese punto de la recuperación.
+func replaySession(file *os.File, sb structures.SuperBlock, entry JournalEntry) *Session {
+
+	session := &Session{Uid: entry.Uid, Gid: entry.Gid}
+
+	store, err := loadUsersStore(file, sb)
+	if err != nil {
+		return session
+	}
+	if user := store.findByID("U", entry.Uid); user != nil {
+		session.User = user.Name
+	}
+	if group := store.findByID("G", entry.Gid); group != nil {
+		session.Group = group.Group
+	}
+	return session
+}
+
+// replayJournalEntry repite una operación con el mismo formato con que la
+// registran los comandos (ver recordJournal en cada uno)
+func replayJournalEntry(session *Session, file *os.File, sb structures.SuperBlock, entry JournalEntry) error {
+
+	switch entry.Operation {
+
+	case "mkfs":
+		return nil
+
+	case "mkgrp", "mkusr", "rmgrp", "rmusr", "chgrp", "passwd":
+		store, err := loadUsersStore(file, sb)
+		if err != nil {
+			return err
+		}
+		if err := replayUsersEntry(store, entry); err != nil {
+			return err
+		}
+		return writeInodeData(file, sb, usersInodeIndex, []byte(store.String()))
+
+	case "mkdir":
+		_, err := traversePath(session, file, sb, entry.Path, true)
+		return err
+
+	case "mkfile":
+		parent, err := traversePath(session, file, sb, path.Dir(entry.Path), true)
+		if err != nil {
+			return err
+		}
+		name := path.Base(entry.Path)
+		if found, inodeIndex := findEntryInDirectory(file, sb, parent, name); found {
+			return overwriteFile(file, sb, inodeIndex, []byte(entry.Content), entry.Path)
+		}
+		_, err = createFile(session, file, sb, parent, name, []byte(entry.Content))
+		return err
+
+	case "edit":
+		inodeIndex, err := resolvePath(session, file, sb, 0, entry.Path)
+		if err != nil {
+			return err
+		}
+		return writeFileContentSafe(file, sb, inodeIndex, []byte(entry.Content))
+
+	case "chmod", "chown":
+		args := strings.Fields(entry.Content)
+		if len(args) == 0 {
+			return fmt.Errorf("operación incompleta")
+		}
+		recursive := len(args) > 1 && args[1] == "-r"
+
+		inodeIndex, err := resolvePath(session, file, sb, 0, entry.Path)
+		if err != nil {
+			return err
+		}
+
+		if entry.Operation == "chmod" {
+			perm, err := parseUGO(args[0])
+			if err != nil {
+				return err
+			}
+			_, err = applyOwnedTree(session, file, sb, inodeIndex, recursive, func(in *structures.Inode) {
+				in.I_perm = perm
+			})
+			return err
+		}
+
+		store, err := loadUsersStore(file, sb)
+		if err != nil {
+			return err
+		}
+		owner := store.findUser(args[0])
+		if owner == nil {
+			return fmt.Errorf("el usuario '%s' no existe", args[0])
+		}
+		_, err = applyOwnedTree(session, file, sb, inodeIndex, recursive, func(in *structures.Inode) {
+			in.I_uid = owner.ID
+		})
+		return err
+
+	case "remove", "rename", "move":
+		parent, err := resolvePath(session, file, sb, 0, path.Dir(entry.Path))
+		if err != nil {
+			return err
+		}
+		name := path.Base(entry.Path)
+		found, inodeIndex := findEntryInDirectory(file, sb, parent, name)
+		if !found {
+			return fmt.Errorf("no existe '%s'", entry.Path)
+		}
+
+		switch entry.Operation {
+		case "remove":
+			removeTree(file, sb, inodeIndex)
+			return removeEntryFromDirectory(file, sb, parent, name)
+
+		case "rename":
+			return renameEntryInDirectory(file, sb, parent, name, entry.Content)
+		}
+
+		dest, err := resolvePath(session, file, sb, 0, entry.Content)
+		if err != nil {
+			return err
+		}
+		if err := addEntryToDirectory(file, sb, dest, name, inodeIndex); err != nil {
+			return err
+		}
+		if err := removeEntryFromDirectory(file, sb, parent, name); err != nil {
+			return err
+		}
+		if inode, err := ReadInode(file, sb, inodeIndex); err == nil && inode.I_type == 0 {
+			return setParentEntry(file, sb, inodeIndex, dest)
+		}
+		return nil
+
+	case "copy":
+		source, err := resolvePath(session, file, sb, 0, entry.Path)
+		if err != nil {
+			return err
+		}
+		dest, err := resolvePath(session, file, sb, 0, entry.Content)
+		if err != nil {
+			return err
+		}
+		skipped := []string{}
+		_, err = copyTree(session, file, sb, source, dest, path.Base(entry.Path), entry.Path, &skipped)
+		return err
+	}
+
+	return fmt.Errorf("operación desconocida")
+}
+
+// replayUsersEntry aplica un registro de users.txt. mkgrp y mkusr guardan la
+// línea completa (con su ID y el hash de la contraseña), passwd la línea del
+// usuario con su nueva contraseña; el resto, nombres.
+func replayUsersEntry(store *usersStore, entry JournalEntry) error {
+
+	switch entry.Operation {
+
+	case "mkgrp", "mkusr":
+		added, err := parseUsers(entry.Content)
+		if err != nil || len(added.records) != 1 {
+			return fmt.Errorf("registro de usuarios inválido")
+		}
+		record := added.records[0]
+		if (record.Kind == "G" && store.findGroup(record.Group) != nil) ||
+			(record.Kind == "U" && store.findUser(record.Name) != nil) {
+			return fmt.Errorf("el registro ya existe")
+		}
+		store.records = append(store.records, record)
+		return nil
+
+	case "passwd":
+		changed, err := parseUsers(entry.Content)
+		if err != nil || len(changed.records) != 1 || changed.records[0].Kind != "U" {
+			return fmt.Errorf("registro de usuarios inválido")
+		}
+		user := store.findUser(changed.records[0].Name)
+		if user == nil {
+			return fmt.Errorf("el usuario '%s' no existe", changed.records[0].Name)
+		}
+		user.Password = changed.records[0].Password
+		return nil
+
+	case "rmgrp":
+		return store.removeGroup(entry.Content)
+
+	case "rmusr":
+		return store.removeUser(entry.Content)
+	}
+
+	user, group, _ := strings.Cut(entry.Content, ",")
+	return store.setUserGroup(user, group)
+}
@@ -0,0 +1,139 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func removeExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de archivos: remove")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	targetPath := strings.TrimSpace(props["path"])
+	if targetPath == "" {
+		return "❌ Error: el parámetro path es obligatorio", true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	defer commitSuperBlock(file, int64(part.Start), &sb)
+
+	cleanPath := path.Clean("/" + targetPath)
+	if cleanPath == "/" {
+		return "❌ Error: no se puede eliminar la raíz", true
+	}
+
+	parentInode, err := resolvePath(ctx.Session, file, sb, 0, path.Dir(cleanPath))
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	name := path.Base(cleanPath)
+	found, inodeIndex := findEntryInDirectory(file, sb, parentInode, name)
+	if !found {
+		return fmt.Sprintf("❌ Error: no existe '%s'", targetPath), true
+	}
+
+	if inodeIndex == usersInodeIndex {
+		return "❌ Error: users.txt no puede eliminarse", true
+	}
+
+	parent, err := ReadInode(file, sb, parentInode)
+	if err != nil {
+		return err.Error(), true
+	}
+	if err := checkPermission(ctx.Session, parent, permWrite, path.Dir(cleanPath)); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	// Primero se valida todo el árbol para no dejar eliminaciones a medias
+	if denied, err := checkRemovable(ctx.Session, file, sb, inodeIndex, cleanPath); err != nil {
+		return err.Error(), true
+	} else if denied != "" {
+		return fmt.Sprintf("❌ Error: sin permiso de escritura en '%s'", denied), true
+	}
+
+	removeTree(file, sb, inodeIndex)
+
+	if err := removeEntryFromDirectory(file, sb, parentInode, name); err != nil {
+		return err.Error(), true
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "remove", cleanPath, ""); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ '%s' eliminado correctamente", targetPath), false
+}
+
+// checkRemovable devuelve la primera ruta del árbol sin permiso de escritura
+func checkRemovable(session *Session, file *os.File, sb structures.SuperBlock, inodeIndex int32, p string) (string, error) {
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return "", err
+	}
+
+	if !hasPermission(session, inode, permWrite) {
+		return p, nil
+	}
+
+	if inode.I_type != 0 {
+		return "", nil
+	}
+
+	for _, entry := range directoryEntries(file, sb, inode) {
+		childPath := path.Join(p, strings.TrimRight(string(entry.B_name[:]), "\x00"))
+		denied, err := checkRemovable(session, file, sb, entry.B_inodo, childPath)
+		if err != nil || denied != "" {
+			return denied, err
+		}
+	}
+
+	return "", nil
+}
+
+// removeTree libera los bloques e inodos del árbol (recursivo en carpetas)
+func removeTree(file *os.File, sb structures.SuperBlock, inodeIndex int32) {
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return
+	}
+
+	if inode.I_type == 0 {
+		for _, entry := range directoryEntries(file, sb, inode) {
+			removeTree(file, sb, entry.B_inodo)
+		}
+	}
+
+	freeInodeBlocks(file, sb, &inode)
+	inode.I_s = 0
+	WriteInode(file, sb, inodeIndex, inode)
+	UnmarkBitmap(file, sb.S_bm_inode_start, inodeIndex)
+}
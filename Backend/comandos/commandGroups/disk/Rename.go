@@ -0,0 +1,97 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func renameExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de archivos: rename")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	targetPath := strings.TrimSpace(props["path"])
+	newName := strings.TrimSpace(props["name"])
+	if targetPath == "" || newName == "" {
+		return "❌ Error: los parámetros path y name son obligatorios", true
+	}
+
+	if err := validateEntryName(newName); err != nil {
+		return err.Error(), true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	cleanPath := path.Clean("/" + targetPath)
+	if cleanPath == "/" {
+		return "❌ Error: no se puede renombrar la raíz", true
+	}
+
+	parentInode, err := resolvePath(ctx.Session, file, sb, 0, path.Dir(cleanPath))
+	if err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	name := path.Base(cleanPath)
+	found, inodeIndex := findEntryInDirectory(file, sb, parentInode, name)
+	if !found {
+		return fmt.Sprintf("❌ Error: no existe '%s'", targetPath), true
+	}
+
+	if inodeIndex == usersInodeIndex {
+		return "❌ Error: users.txt no puede renombrarse", true
+	}
+
+	if exists, _ := findEntryInDirectory(file, sb, parentInode, newName); exists {
+		return fmt.Sprintf("❌ Error: ya existe '%s' en '%s'", newName, path.Dir(cleanPath)), true
+	}
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err.Error(), true
+	}
+	parent, err := ReadInode(file, sb, parentInode)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	if err := checkPermission(ctx.Session, inode, permWrite, targetPath); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+	if err := checkPermission(ctx.Session, parent, permWrite, path.Dir(cleanPath)); err != nil {
+		return "❌ Error: " + err.Error(), true
+	}
+
+	if err := renameEntryInDirectory(file, sb, parentInode, name, newName); err != nil {
+		return err.Error(), true
+	}
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "rename", cleanPath, newName); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ '%s' renombrado a '%s'", targetPath, newName), false
+}
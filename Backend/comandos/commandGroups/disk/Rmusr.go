@@ -0,0 +1,70 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func rmusrExecute(ctx *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Administración de usuarios: rmusr")
+	color.Green("-----------------------------------------------------------")
+
+	if ctx.Session == nil {
+		return "❌ Error: no hay una sesión activa", true
+	}
+
+	if ctx.Session.User != "root" {
+		return "❌ Error: solo el usuario root puede eliminar usuarios", true
+	}
+
+	userName := strings.TrimSpace(props["user"])
+	if userName == "" {
+		return "❌ Error: el parámetro user es obligatorio", true
+	}
+
+	part := GetMountedPartition(ctx.Session.Id)
+	if part == nil {
+		return "❌ Error: la partición no está montada", true
+	}
+
+	file, release, err := openDisk(part.Path, true)
+	if err != nil {
+		return "❌ Error al abrir el disco", true
+	}
+	defer release()
+
+	var sb structures.SuperBlock
+	if err := ReadSuperBlock(file, int64(part.Start), &sb); err != nil {
+		return "❌ Error al leer el SuperBloque", true
+	}
+
+	store, err := loadUsersStore(file, sb)
+	if err != nil {
+		return err.Error(), true
+	}
+
+	// Se marca como eliminado con ID 0 sin borrar la línea
+	if err := store.removeUser(userName); err != nil {
+		return err.Error(), true
+	}
+
+	if err := store.save(file, int64(part.Start), &sb); err != nil {
+		return err.Error(), true
+	}
+
+	// Un usuario eliminado no conserva sus sesiones abiertas
+	updateUserSessions(part.Id, userName, func(*Session) bool {
+		return false
+	})
+
+	if err := recordJournal(ctx.Session, file, int64(part.Start), sb, "rmusr", usersFilePath, userName); err != nil {
+		return err.Error(), true
+	}
+
+	return fmt.Sprintf("✅ Usuario '%s' eliminado correctamente", userName), false
+}
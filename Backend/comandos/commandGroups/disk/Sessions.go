@@ -0,0 +1,157 @@
+package disk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+/* =========================
+   SESIONES POR CLIENTE
+========================= */
+
+type Session struct {
+	User  string
+	Group string
+	Id    string
+	Uid   int32
+	Gid   int32
+	Token string
+
+	lastSeen time.Time
+}
+
+// SessionIdleTimeout es el tiempo sin actividad tras el cual expira una sesión
+var SessionIdleTimeout = 30 * time.Minute
+
+var (
+	sessionsMu sync.Mutex
+	sessions   = map[string]*Session{}
+)
+
+// CommandContext acompaña a los comandos de una misma petición: login
+// asigna la sesión y los comandos siguientes la usan
+type CommandContext struct {
+	Session *Session
+}
+
+// NewCommandContext resuelve el token del cliente (vacío si no tiene)
+func NewCommandContext(token string) *CommandContext {
+	return &CommandContext{Session: ResolveSession(token)}
+}
+
+// Token devuelve el token de la sesión vigente, o "" si no hay sesión
+func (ctx *CommandContext) Token() string {
+	if ctx == nil || ctx.Session == nil {
+		return ""
+	}
+	return ctx.Session.Token
+}
+
+// ResolveSession busca la sesión del token y renueva su actividad.
+// Devuelve nil si no existe o ya expiró.
+func ResolveSession(token string) *Session {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil
+	}
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	session, ok := sessions[token]
+	if !ok {
+		return nil
+	}
+
+	if time.Since(session.lastSeen) > SessionIdleTimeout {
+		delete(sessions, token)
+		return nil
+	}
+
+	session.lastSeen = time.Now()
+	return session
+}
+
+// startSession registra la sesión con un token nuevo
+func startSession(session *Session) (*Session, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	session.Token = hex.EncodeToString(buf)
+	session.lastSeen = time.Now()
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	// Se aprovecha para descartar las sesiones expiradas
+	for token, s := range sessions {
+		if time.Since(s.lastSeen) > SessionIdleTimeout {
+			delete(sessions, token)
+		}
+	}
+
+	sessions[session.Token] = session
+	return session, nil
+}
+
+func endSession(session *Session) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	delete(sessions, session.Token)
+}
+
+// sessionOnPartition indica si algún cliente tiene sesión en la partición
+// (o en cualquier partición del disco si id es vacío)
+func sessionOnPartition(diskPath string, id string) bool {
+	sessionsMu.Lock()
+	var ids []string
+	for _, s := range sessions {
+		if time.Since(s.lastSeen) <= SessionIdleTimeout {
+			ids = append(ids, s.Id)
+		}
+	}
+	sessionsMu.Unlock()
+
+	// Las particiones se consultan sin sostener sessionsMu
+	for _, sessionId := range ids {
+		if id != "" {
+			if strings.EqualFold(sessionId, id) {
+				return true
+			}
+			continue
+		}
+
+		if part := GetMountedPartition(sessionId); part != nil && part.Path == diskPath {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateUserSessions aplica fn a las sesiones de user en la partición id.
+// Cada sesión se reemplaza por una copia para no alterar la que una petición
+// en curso esté usando; si fn devuelve false la sesión se cierra.
+func updateUserSessions(id string, user string, fn func(*Session) bool) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	for token, s := range sessions {
+		if s.User != user || !strings.EqualFold(s.Id, id) {
+			continue
+		}
+
+		updated := *s
+		if !fn(&updated) {
+			delete(sessions, token)
+			continue
+		}
+		sessions[token] = &updated
+	}
+}
@@ -0,0 +1,53 @@
+package disk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+func unmountExecute(_ *CommandContext, _ string, props map[string]string) (string, bool) {
+
+	id := strings.TrimSpace(props["id"])
+	if id == "" {
+		return "Error: el parámetro id es obligatorio", true
+	}
+
+	part := GetMountedPartition(id)
+	if part == nil {
+		return fmt.Sprintf("No existe una partición montada con ID %s", id), true
+	}
+
+	defer LockDisk(part.Path)()
+
+	if sessionOnPartition(part.Path, part.Id) {
+		return "No se puede desmontar la partición de la sesión activa", true
+	}
+
+	mountedMu.Lock()
+	defer mountedMu.Unlock()
+
+	// Otra petición pudo desmontarla mientras se esperaba el candado
+	index := indiceMontada(id)
+	if index == -1 {
+		return fmt.Sprintf("No existe una partición montada con ID %s", id), true
+	}
+
+	if msg, er := marcarMontaje(part.Path, part.Name, -1, false); er {
+		return msg, true
+	}
+	actualizarSuperBloqueMontaje(part.Path, part.Start, false)
+	dropBitmaps(part.Path)
+
+	mountedPartitions = append(mountedPartitions[:index], mountedPartitions[index+1:]...)
+
+	color.Green("-----------------------------------------------------------")
+	color.Blue("Partición desmontada correctamente")
+	color.Blue("Disco: %s", part.DiskName)
+	color.Blue("Partición: %s", part.Name)
+	color.Blue("ID: %s", part.Id)
+	color.Green("-----------------------------------------------------------")
+
+	return fmt.Sprintf("Partición %s desmontada correctamente", part.Id), false
+}
@@ -0,0 +1,173 @@
+package disk
+
+import (
+	"os"
+	"sync"
+
+	"Proyecto/Estructuras/structures"
+)
+
+/* =========================
+   CACHÉ DE BITMAPS
+========================= */
+
+// Cada bitmap (de inodos o de bloques) de una partición montada se carga
+// completo en memoria la primera vez que se usa. Las búsquedas y marcas
+// trabajan sobre la copia y release de openDisk escribe de una sola vez el
+// rango modificado, así que el archivo sigue siendo la fuente de verdad
+// para quien lo lea sin el candado de escritura (reportes, fdisk).
+//
+// El contenido sólo cambia con el candado de escritura del disco tomado;
+// bitmapCachesMu protege únicamente el mapa.
+type bitmapKey struct {
+	disk  string
+	start int32
+}
+
+type bitmapCache struct {
+	bits []byte
+	next int32 // primer índice posiblemente libre
+
+	dirtyFrom int32
+	dirtyTo   int32 // exclusivo; dirtyFrom == dirtyTo si no hay cambios
+}
+
+var (
+	bitmapCachesMu sync.Mutex
+	bitmapCaches   = map[bitmapKey]*bitmapCache{}
+)
+
+// loadBitmap devuelve la caché del bitmap que empieza en start, leyéndolo
+// del disco si aún no está cargado o si cambió de tamaño. first es el punto
+// de partida de las búsquedas (S_first_ino / S_first_blo).
+func loadBitmap(file *os.File, start int32, count int32, first int32) *bitmapCache {
+	key := bitmapKey{diskKey(file.Name()), start}
+
+	bitmapCachesMu.Lock()
+	defer bitmapCachesMu.Unlock()
+
+	if cache, ok := bitmapCaches[key]; ok && int32(len(cache.bits)) == count {
+		return cache
+	}
+
+	bits := make([]byte, count)
+	if _, err := file.ReadAt(bits, int64(start)); err != nil {
+		return nil
+	}
+
+	if first < 0 || first > count {
+		first = 0
+	}
+
+	cache := &bitmapCache{bits: bits, next: first}
+	bitmapCaches[key] = cache
+	return cache
+}
+
+func cachedBitmap(file *os.File, start int32) *bitmapCache {
+	bitmapCachesMu.Lock()
+	defer bitmapCachesMu.Unlock()
+
+	return bitmapCaches[bitmapKey{diskKey(file.Name()), start}]
+}
+
+// findFree busca desde next hasta el final y luego desde el inicio
+func (c *bitmapCache) findFree() int32 {
+	if c == nil {
+		return -1
+	}
+
+	count := int32(len(c.bits))
+	for n := int32(0); n < count; n++ {
+		i := (c.next + n) % count
+		if c.bits[i] == 0 {
+			c.next = i
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *bitmapCache) set(index int32, value byte) {
+	if index < 0 || index >= int32(len(c.bits)) || c.bits[index] == value {
+		return
+	}
+
+	c.bits[index] = value
+
+	if value == 0 && index < c.next {
+		c.next = index
+	} else if value != 0 && index == c.next {
+		c.next++
+	}
+
+	if c.dirtyFrom == c.dirtyTo {
+		c.dirtyFrom, c.dirtyTo = index, index+1
+		return
+	}
+	if index < c.dirtyFrom {
+		c.dirtyFrom = index
+	}
+	if index+1 > c.dirtyTo {
+		c.dirtyTo = index + 1
+	}
+}
+
+// freeCount devuelve los libres y el primero libre (len si no hay)
+func (c *bitmapCache) freeCount() (free int32, first int32) {
+	first = int32(len(c.bits))
+	for i, b := range c.bits {
+		if b == 0 {
+			if free == 0 {
+				first = int32(i)
+			}
+			free++
+		}
+	}
+	return free, first
+}
+
+// flushBitmaps escribe el rango modificado de cada bitmap del disco
+func flushBitmaps(file *os.File) {
+	disk := diskKey(file.Name())
+
+	bitmapCachesMu.Lock()
+	defer bitmapCachesMu.Unlock()
+
+	for key, cache := range bitmapCaches {
+		if key.disk != disk || cache.dirtyFrom == cache.dirtyTo {
+			continue
+		}
+
+		chunk := cache.bits[cache.dirtyFrom:cache.dirtyTo]
+		if _, err := file.WriteAt(chunk, int64(key.start+cache.dirtyFrom)); err != nil {
+			// Se descarta la caché para que la siguiente lectura use el disco
+			delete(bitmapCaches, key)
+			continue
+		}
+		cache.dirtyFrom, cache.dirtyTo = 0, 0
+	}
+}
+
+// dropBitmaps descarta las cachés de un disco. Se usa cuando los bitmaps se
+// reescriben sin pasar por la caché (mkfs, fdisk) o dejan de estar montados.
+func dropBitmaps(path string) {
+	disk := diskKey(path)
+
+	bitmapCachesMu.Lock()
+	defer bitmapCachesMu.Unlock()
+
+	for key := range bitmapCaches {
+		if key.disk == disk {
+			delete(bitmapCaches, key)
+		}
+	}
+}
+
+func inodeBitmap(file *os.File, sb structures.SuperBlock) *bitmapCache {
+	return loadBitmap(file, sb.S_bm_inode_start, sb.S_inodes_count, sb.S_first_ino)
+}
+
+func blockBitmap(file *os.File, sb structures.SuperBlock) *bitmapCache {
+	return loadBitmap(file, sb.S_bm_block_start, sb.S_blocks_count, sb.S_first_blo)
+}
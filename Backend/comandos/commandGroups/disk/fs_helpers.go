@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"Proyecto/Estructuras/structures"
 )
@@ -20,6 +21,38 @@ func ReadSuperBlock(file *os.File, start int64, sb *structures.SuperBlock) error
 	return nil
 }
 
+func WriteSuperBlock(file *os.File, start int64, sb *structures.SuperBlock) error {
+	if _, err := file.Seek(start, 0); err != nil {
+		return fmt.Errorf("error al posicionar el SuperBloque")
+	}
+	if err := binary.Write(file, binary.LittleEndian, sb); err != nil {
+		return fmt.Errorf("error al escribir el SuperBloque")
+	}
+	return nil
+}
+
+// refreshFreeCounts recalcula los contadores de libres y el primer inodo y
+// bloque libres (S_first_ino / S_first_blo) a partir de los bitmaps. Si no
+// queda ninguno libre, el primero apunta al total.
+func refreshFreeCounts(file *os.File, sb *structures.SuperBlock) {
+	if bm := inodeBitmap(file, *sb); bm != nil {
+		sb.S_free_inodes_count, sb.S_first_ino = bm.freeCount()
+		bm.next = sb.S_first_ino
+	}
+	if bm := blockBitmap(file, *sb); bm != nil {
+		sb.S_free_blocks_count, sb.S_first_blo = bm.freeCount()
+		bm.next = sb.S_first_blo
+	}
+}
+
+// commitSuperBlock actualiza los contadores y guarda el SuperBloque. Los
+// comandos que reservan o liberan inodos/bloques lo difieren al abrir el
+// disco para que quede correcto incluso si fallan a mitad.
+func commitSuperBlock(file *os.File, start int64, sb *structures.SuperBlock) error {
+	refreshFreeCounts(file, sb)
+	return WriteSuperBlock(file, start, sb)
+}
+
 // INODOS
 func ReadInode(file *os.File, sb structures.SuperBlock, inodeIndex int32) (structures.Inode, error) {
 	var inode structures.Inode
@@ -72,42 +105,31 @@ func WriteBlock(file *os.File, sb structures.SuperBlock, blockIndex int32, data
 }
 
 // BITMAPS
+// Las búsquedas empiezan en S_first_ino / S_first_blo y usan la caché en
+// memoria de la partición (ver bitmap_cache.go)
 func FindFreeInode(file *os.File, sb structures.SuperBlock) int32 {
-	for i := int32(0); i < sb.S_inodes_count; i++ {
-		pos := sb.S_bm_inode_start + i
-		file.Seek(int64(pos), 0)
-		b := []byte{0}
-		file.Read(b)
-		if b[0] == 0 {
-			return i
-		}
-	}
-	return -1
+	return inodeBitmap(file, sb).findFree()
 }
 
 func FindFreeBlock(file *os.File, sb structures.SuperBlock) int32 {
-	for i := int32(0); i < sb.S_blocks_count; i++ {
-		pos := sb.S_bm_block_start + i
-		file.Seek(int64(pos), 0)
-		b := []byte{0}
-		file.Read(b)
-		if b[0] == 0 {
-			return i
-		}
-	}
-	return -1
+	return blockBitmap(file, sb).findFree()
 }
 
 func MarkBitmap(file *os.File, bmStart int32, index int32) {
-	pos := bmStart + index
-	file.Seek(int64(pos), 0)
-	file.Write([]byte{1})
+	setBitmap(file, bmStart, index, 1)
 }
 
 func UnmarkBitmap(file *os.File, bmStart int32, index int32) {
-	pos := bmStart + index
-	file.Seek(int64(pos), 0)
-	file.Write([]byte{0})
+	setBitmap(file, bmStart, index, 0)
+}
+
+// setBitmap escribe directo en el disco sólo si el bitmap no está en caché
+func setBitmap(file *os.File, bmStart int32, index int32, value byte) {
+	if cache := cachedBitmap(file, bmStart); cache != nil {
+		cache.set(index, value)
+		return
+	}
+	file.WriteAt([]byte{value}, int64(bmStart+index))
 }
 
 // DIRECTORIOS
@@ -127,11 +149,7 @@ func findEntryInDirectory(
 		return false, -1
 	}
 
-	for _, blk := range inode.I_block {
-		if blk == -1 {
-			continue
-		}
-
+	for _, blk := range inodeDataBlocks(file, sb, inode) {
 		var folder structures.BloqueCarpeta
 		ReadBlock(file, sb, blk, &folder)
 
@@ -146,7 +164,51 @@ func findEntryInDirectory(
 	return false, -1
 }
 
+// resolvePath resuelve una ruta absoluta, o relativa a start, siguiendo
+// las entradas "." y ".." guardadas en cada carpeta.
+func resolvePath(
+	session *Session,
+	file *os.File,
+	sb structures.SuperBlock,
+	start int32,
+	p string,
+) (int32, error) {
+
+	current := start
+	currentName := "/"
+	if strings.HasPrefix(p, "/") {
+		current = 0
+	}
+
+	for _, name := range strings.Split(p, "/") {
+		if name == "" || name == "." {
+			continue
+		}
+
+		inode, err := ReadInode(file, sb, current)
+		if err != nil {
+			return -1, err
+		}
+		if inode.I_type != 0 {
+			return -1, fmt.Errorf("'%s' no es una carpeta", currentName)
+		}
+		if err := checkPermission(session, inode, permExec, currentName); err != nil {
+			return -1, err
+		}
+
+		found, next := findEntryInDirectory(file, sb, current, name)
+		if !found {
+			return -1, fmt.Errorf("no existe '%s'", name)
+		}
+		current = next
+		currentName = name
+	}
+
+	return current, nil
+}
+
 func traversePath(
+	session *Session,
 	file *os.File,
 	sb structures.SuperBlock,
 	p string,
@@ -159,29 +221,64 @@ func traversePath(
 
 	parts := strings.Split(strings.Trim(p, "/"), "/")
 	current := int32(0)
+	currentName := "/"
 
 	for _, dir := range parts {
+		if dir == "" || dir == "." {
+			continue
+		}
+
+		if err := requireFolder(file, sb, current, currentName); err != nil {
+			return -1, err
+		}
+		if err := checkInodePermission(session, file, sb, current, permExec, currentName); err != nil {
+			return -1, fmt.Errorf("❌ Error: %s", err)
+		}
+
 		found, inode := findEntryInDirectory(file, sb, current, dir)
 		if found {
 			current = inode
+			currentName = dir
 			continue
 		}
 
-		if !create {
+		if !create || dir == ".." {
 			return -1, fmt.Errorf("❌ Error: la carpeta '%s' no existe", dir)
 		}
 
-		newInode, err := createDirectory(file, sb, current, dir)
+		if err := checkInodePermission(session, file, sb, current, permWrite, currentName); err != nil {
+			return -1, fmt.Errorf("❌ Error: %s", err)
+		}
+
+		newInode, err := createDirectory(session, file, sb, current, dir)
 		if err != nil {
 			return -1, err
 		}
 		current = newInode
+		currentName = dir
 	}
 
+	if err := requireFolder(file, sb, current, currentName); err != nil {
+		return -1, err
+	}
 	return current, nil
 }
 
+// requireFolder verifica que el inodo sea una carpeta antes de buscar o
+// agregar entradas en él
+func requireFolder(file *os.File, sb structures.SuperBlock, inodeIndex int32, name string) error {
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err
+	}
+	if inode.I_type != 0 {
+		return fmt.Errorf("❌ Error: '%s' no es una carpeta", name)
+	}
+	return nil
+}
+
 func createDirectory(
+	session *Session,
 	file *os.File,
 	sb structures.SuperBlock,
 	parent int32,
@@ -195,9 +292,19 @@ func createDirectory(
 		return -1, fmt.Errorf("❌ Error: no hay espacio para crear carpeta")
 	}
 
+	now := int32(time.Now().Unix())
+
 	var inode structures.Inode
 	inode.I_type = 0
 	inode.I_perm = [3]byte{7, 7, 5}
+	if session != nil {
+		inode.I_uid = session.Uid
+		inode.I_gid = session.Gid
+	}
+	inode.I_s = sb.S_block_s
+	inode.I_atime = now
+	inode.I_ctime = now
+	inode.I_mtime = now
 	inode.I_block[0] = newBlock
 	for i := 1; i < 15; i++ {
 		inode.I_block[i] = -1
@@ -207,13 +314,20 @@ func createDirectory(
 	MarkBitmap(file, sb.S_bm_inode_start, newInode)
 	MarkBitmap(file, sb.S_bm_block_start, newBlock)
 
-	var folder structures.BloqueCarpeta
-	for i := 0; i < 4; i++ {
-		folder.B_content[i].B_inodo = -1
-	}
-
+	// Toda carpeta inicia con su propia entrada y la de su padre
+	folder := newFolderBlock()
+	copy(folder.B_content[0].B_name[:], ".")
+	folder.B_content[0].B_inodo = newInode
+	copy(folder.B_content[1].B_name[:], "..")
+	folder.B_content[1].B_inodo = parent
 	WriteBlock(file, sb, newBlock, &folder)
-	addEntryToDirectory(file, sb, parent, name, newInode)
+
+	if err := addEntryToDirectory(file, sb, parent, name, newInode); err != nil {
+		// Se revierte la reserva si el padre no admite la entrada
+		UnmarkBitmap(file, sb.S_bm_block_start, newBlock)
+		UnmarkBitmap(file, sb.S_bm_inode_start, newInode)
+		return -1, err
+	}
 
 	return newInode, nil
 }
@@ -226,20 +340,21 @@ func addEntryToDirectory(
 	childInode int32,
 ) error {
 
-	if name == "" {
-		return fmt.Errorf("nombre de entrada vacío")
+	if err := validateEntryName(name); err != nil {
+		return err
 	}
 
 	parent, err := ReadInode(file, sb, parentInode)
 	if err != nil {
 		return err
 	}
+	if parent.I_type != 0 {
+		return fmt.Errorf("❌ Error: el destino de '%s' no es una carpeta", name)
+	}
 
-	for _, blk := range parent.I_block {
-		if blk == -1 {
-			continue
-		}
+	blocks := inodeDataBlocks(file, sb, parent)
 
+	for _, blk := range blocks {
 		var folder structures.BloqueCarpeta
 		ReadBlock(file, sb, blk, &folder)
 
@@ -252,5 +367,480 @@ func addEntryToDirectory(
 		}
 	}
 
-	return fmt.Errorf("Error: no hay espacio en el directorio")
+	// Directorio lleno: se agrega un nuevo bloque carpeta
+	blk, err := inodeBlockAt(file, sb, &parent, int32(len(blocks)), true)
+	if err != nil {
+		WriteInode(file, sb, parentInode, parent)
+		return fmt.Errorf("❌ Error: no hay espacio en el directorio")
+	}
+
+	folder := newFolderBlock()
+	copy(folder.B_content[0].B_name[:], name)
+	folder.B_content[0].B_inodo = childInode
+
+	if err := WriteBlock(file, sb, blk, &folder); err != nil {
+		return err
+	}
+
+	parent.I_s += sb.S_block_s
+	parent.I_mtime = int32(time.Now().Unix())
+	return WriteInode(file, sb, parentInode, parent)
+}
+
+// validateEntryName verifica que el nombre quepa en B_name (12 bytes)
+func validateEntryName(name string) error {
+	if name == "" {
+		return fmt.Errorf("nombre de entrada vacío")
+	}
+	if name == "." || name == ".." || strings.Contains(name, "/") {
+		return fmt.Errorf("❌ Error: nombre inválido '%s'", name)
+	}
+	if len(name) > len(structures.Content{}.B_name) {
+		return fmt.Errorf("❌ Error: el nombre '%s' excede %d caracteres", name, len(structures.Content{}.B_name))
+	}
+	return nil
+}
+
+// renameEntryInDirectory cambia el nombre de una entrada de la carpeta
+func renameEntryInDirectory(
+	file *os.File,
+	sb structures.SuperBlock,
+	dirInode int32,
+	oldName string,
+	newName string,
+) error {
+
+	dir, err := ReadInode(file, sb, dirInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blk := range inodeDataBlocks(file, sb, dir) {
+		var folder structures.BloqueCarpeta
+		if err := ReadBlock(file, sb, blk, &folder); err != nil {
+			return err
+		}
+
+		for i := range folder.B_content {
+			entryName := strings.TrimRight(string(folder.B_content[i].B_name[:]), "\x00")
+			if folder.B_content[i].B_inodo != -1 && entryName == oldName {
+				folder.B_content[i].B_name = [12]byte{}
+				copy(folder.B_content[i].B_name[:], newName)
+				if err := WriteBlock(file, sb, blk, &folder); err != nil {
+					return err
+				}
+
+				dir.I_mtime = int32(time.Now().Unix())
+				return WriteInode(file, sb, dirInode, dir)
+			}
+		}
+	}
+
+	return fmt.Errorf("no existe '%s'", oldName)
+}
+
+// isSameOrDescendant indica si dirInode es ancestro (o igual) de target,
+// subiendo por las entradas ".." hasta la raíz.
+func isSameOrDescendant(file *os.File, sb structures.SuperBlock, ancestor int32, target int32) bool {
+	current := target
+	for steps := int32(0); steps <= sb.S_inodes_count; steps++ {
+		if current == ancestor {
+			return true
+		}
+		if current == 0 {
+			return false
+		}
+
+		found, parent := findEntryInDirectory(file, sb, current, "..")
+		if !found {
+			return false
+		}
+		current = parent
+	}
+	return false
+}
+
+// removeEntryFromDirectory elimina la entrada name de la carpeta padre
+func removeEntryFromDirectory(
+	file *os.File,
+	sb structures.SuperBlock,
+	parentInode int32,
+	name string,
+) error {
+
+	parent, err := ReadInode(file, sb, parentInode)
+	if err != nil {
+		return err
+	}
+
+	for _, blk := range inodeDataBlocks(file, sb, parent) {
+		var folder structures.BloqueCarpeta
+		if err := ReadBlock(file, sb, blk, &folder); err != nil {
+			return err
+		}
+
+		for i := range folder.B_content {
+			entryName := strings.TrimRight(string(folder.B_content[i].B_name[:]), "\x00")
+			if folder.B_content[i].B_inodo != -1 && entryName == name {
+				folder.B_content[i].B_name = [12]byte{}
+				folder.B_content[i].B_inodo = -1
+				if err := WriteBlock(file, sb, blk, &folder); err != nil {
+					return err
+				}
+
+				parent.I_mtime = int32(time.Now().Unix())
+				return WriteInode(file, sb, parentInode, parent)
+			}
+		}
+	}
+
+	return fmt.Errorf("no existe '%s'", name)
+}
+
+// directoryEntries devuelve las entradas de la carpeta sin "." ni ".."
+func directoryEntries(file *os.File, sb structures.SuperBlock, dir structures.Inode) []structures.Content {
+	var entries []structures.Content
+
+	for _, blk := range inodeDataBlocks(file, sb, dir) {
+		var folder structures.BloqueCarpeta
+		if err := ReadBlock(file, sb, blk, &folder); err != nil {
+			continue
+		}
+
+		for _, entry := range folder.B_content {
+			name := strings.TrimRight(string(entry.B_name[:]), "\x00")
+			if entry.B_inodo == -1 || name == "." || name == ".." {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+func newFolderBlock() structures.BloqueCarpeta {
+	var folder structures.BloqueCarpeta
+	for i := range folder.B_content {
+		folder.B_content[i].B_inodo = -1
+	}
+	return folder
+}
+
+// BLOQUES DE UN INODO
+// I_block[0..11] son directos, I_block[12] indirecto simple,
+// I_block[13] indirecto doble e I_block[14] indirecto triple.
+const (
+	directBlocks     = 12
+	pointersPerBlock = 16
+)
+
+func allocateBlock(file *os.File, sb structures.SuperBlock) int32 {
+	blk := FindFreeBlock(file, sb)
+	if blk != -1 {
+		MarkBitmap(file, sb.S_bm_block_start, blk)
+	}
+	return blk
+}
+
+func newPointerBlock() structures.BloqueApuntador {
+	var ptr structures.BloqueApuntador
+	for i := range ptr.B_pointers {
+		ptr.B_pointers[i] = -1
+	}
+	return ptr
+}
+
+// pointerSpan devuelve cuántos bloques de datos cubre un apuntador de ese nivel
+func pointerSpan(level int) int32 {
+	span := int32(1)
+	for i := 0; i < level; i++ {
+		span *= pointersPerBlock
+	}
+	return span
+}
+
+// maxInodeBlocks es la cantidad de bloques de datos que admite un inodo
+var maxInodeBlocks = directBlocks + pointerSpan(1) + pointerSpan(2) + pointerSpan(3)
+
+func validBlock(sb structures.SuperBlock, blk int32) bool {
+	return blk >= 0 && blk < sb.S_blocks_count
+}
+
+// inodeBlockAt devuelve el bloque de datos número n del inodo. Con allocate
+// reserva el bloque de datos y los apuntadores intermedios que falten; el
+// llamador debe escribir el inodo después.
+func inodeBlockAt(
+	file *os.File,
+	sb structures.SuperBlock,
+	inode *structures.Inode,
+	n int32,
+	allocate bool,
+) (int32, error) {
+
+	if n < directBlocks {
+		if inode.I_block[n] == -1 && allocate {
+			blk := allocateBlock(file, sb)
+			if blk == -1 {
+				return -1, fmt.Errorf("❌ Error: no hay bloques libres")
+			}
+			inode.I_block[n] = blk
+		}
+		return inode.I_block[n], nil
+	}
+
+	n -= directBlocks
+	for level := 1; level <= 3; level++ {
+		if n < pointerSpan(level) {
+			return resolvePointerBlock(file, sb, &inode.I_block[directBlocks+level-1], level, n, allocate)
+		}
+		n -= pointerSpan(level)
+	}
+
+	return -1, fmt.Errorf("❌ Error: se excede la capacidad máxima del inodo")
+}
+
+func resolvePointerBlock(
+	file *os.File,
+	sb structures.SuperBlock,
+	slot *int32,
+	level int,
+	n int32,
+	allocate bool,
+) (int32, error) {
+
+	if *slot == -1 {
+		if !allocate {
+			return -1, nil
+		}
+
+		blk := allocateBlock(file, sb)
+		if blk == -1 {
+			return -1, fmt.Errorf("❌ Error: no hay bloques libres")
+		}
+
+		ptr := newPointerBlock()
+		if err := WriteBlock(file, sb, blk, &ptr); err != nil {
+			return -1, err
+		}
+		*slot = blk
+	}
+
+	var ptr structures.BloqueApuntador
+	if err := ReadBlock(file, sb, *slot, &ptr); err != nil {
+		return -1, err
+	}
+
+	span := pointerSpan(level - 1)
+	index := n / span
+	child := ptr.B_pointers[index]
+
+	var result int32
+	var err error
+
+	if level == 1 {
+		if child == -1 && allocate {
+			child = allocateBlock(file, sb)
+			if child == -1 {
+				return -1, fmt.Errorf("❌ Error: no hay bloques libres")
+			}
+		}
+		result = child
+	} else {
+		result, err = resolvePointerBlock(file, sb, &child, level-1, n%span, allocate)
+	}
+
+	if child != ptr.B_pointers[index] {
+		ptr.B_pointers[index] = child
+		if werr := WriteBlock(file, sb, *slot, &ptr); werr != nil {
+			return -1, werr
+		}
+	}
+
+	return result, err
+}
+
+// WalkInodeBlocks recorre en orden todos los bloques del inodo. level es 0
+// para bloques de datos y 1..3 para bloques de apuntadores.
+func WalkInodeBlocks(
+	file *os.File,
+	sb structures.SuperBlock,
+	inode structures.Inode,
+	fn func(blk int32, level int),
+) {
+	for i := 0; i < directBlocks; i++ {
+		if validBlock(sb, inode.I_block[i]) {
+			fn(inode.I_block[i], 0)
+		}
+	}
+
+	for level := 1; level <= 3; level++ {
+		walkPointerBlock(file, sb, inode.I_block[directBlocks+level-1], level, fn)
+	}
+}
+
+func walkPointerBlock(
+	file *os.File,
+	sb structures.SuperBlock,
+	blk int32,
+	level int,
+	fn func(blk int32, level int),
+) {
+	if !validBlock(sb, blk) {
+		return
+	}
+
+	fn(blk, level)
+
+	var ptr structures.BloqueApuntador
+	if err := ReadBlock(file, sb, blk, &ptr); err != nil {
+		return
+	}
+
+	for _, child := range ptr.B_pointers {
+		if level == 1 {
+			if validBlock(sb, child) {
+				fn(child, 0)
+			}
+		} else {
+			walkPointerBlock(file, sb, child, level-1, fn)
+		}
+	}
+}
+
+// inodeDataBlocks devuelve los bloques de datos del inodo en orden lógico
+func inodeDataBlocks(file *os.File, sb structures.SuperBlock, inode structures.Inode) []int32 {
+	var blocks []int32
+	WalkInodeBlocks(file, sb, inode, func(blk int32, level int) {
+		if level == 0 {
+			blocks = append(blocks, blk)
+		}
+	})
+	return blocks
+}
+
+// truncateInodeBlocks conserva los primeros keep bloques de datos y libera
+// el resto, junto con los bloques de apuntadores que queden vacíos.
+func truncateInodeBlocks(file *os.File, sb structures.SuperBlock, inode *structures.Inode, keep int32) {
+	for i := int32(0); i < directBlocks; i++ {
+		if i >= keep && inode.I_block[i] != -1 {
+			UnmarkBitmap(file, sb.S_bm_block_start, inode.I_block[i])
+			inode.I_block[i] = -1
+		}
+	}
+
+	keep -= directBlocks
+	for level := 1; level <= 3; level++ {
+		truncatePointerBlock(file, sb, &inode.I_block[directBlocks+level-1], level, keep)
+		keep -= pointerSpan(level)
+	}
+}
+
+func truncatePointerBlock(file *os.File, sb structures.SuperBlock, slot *int32, level int, keep int32) {
+	if *slot == -1 {
+		return
+	}
+
+	var ptr structures.BloqueApuntador
+	if err := ReadBlock(file, sb, *slot, &ptr); err != nil {
+		return
+	}
+
+	span := pointerSpan(level - 1)
+	for i := range ptr.B_pointers {
+		if ptr.B_pointers[i] == -1 {
+			continue
+		}
+
+		childKeep := keep - int32(i)*span
+		if level == 1 {
+			if childKeep <= 0 {
+				UnmarkBitmap(file, sb.S_bm_block_start, ptr.B_pointers[i])
+				ptr.B_pointers[i] = -1
+			}
+		} else {
+			truncatePointerBlock(file, sb, &ptr.B_pointers[i], level-1, childKeep)
+		}
+	}
+
+	if keep <= 0 {
+		UnmarkBitmap(file, sb.S_bm_block_start, *slot)
+		*slot = -1
+		return
+	}
+
+	WriteBlock(file, sb, *slot, &ptr)
+}
+
+// freeInodeBlocks libera todos los bloques (datos y apuntadores) del inodo
+func freeInodeBlocks(file *os.File, sb structures.SuperBlock, inode *structures.Inode) {
+	truncateInodeBlocks(file, sb, inode, 0)
+}
+
+// CONTENIDO DE ARCHIVOS
+func readInodeData(file *os.File, sb structures.SuperBlock, inode structures.Inode) ([]byte, error) {
+	data := make([]byte, 0, inode.I_s)
+
+	for _, blk := range inodeDataBlocks(file, sb, inode) {
+		if int32(len(data)) >= inode.I_s {
+			break
+		}
+
+		var fb structures.BloqueArchivo
+		if err := ReadBlock(file, sb, blk, &fb); err != nil {
+			return nil, err
+		}
+
+		end := inode.I_s - int32(len(data))
+		if end > int32(len(fb.B_content)) {
+			end = int32(len(fb.B_content))
+		}
+		data = append(data, fb.B_content[:end]...)
+	}
+
+	return data, nil
+}
+
+// writeInodeData reemplaza el contenido del archivo reutilizando sus bloques,
+// reservando los que falten y liberando los sobrantes.
+func writeInodeData(file *os.File, sb structures.SuperBlock, inodeIndex int32, data []byte) error {
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err
+	}
+
+	blockSize := int32(len(structures.BloqueArchivo{}.B_content))
+	needed := (int32(len(data)) + blockSize - 1) / blockSize
+
+	if needed > maxInodeBlocks {
+		return fmt.Errorf("❌ Error: se excede la capacidad máxima del inodo")
+	}
+
+	for n := int32(0); n < needed; n++ {
+		blk, err := inodeBlockAt(file, sb, &inode, n, true)
+		if err != nil {
+			WriteInode(file, sb, inodeIndex, inode)
+			return err
+		}
+
+		var fb structures.BloqueArchivo
+		copy(fb.B_content[:], data[n*blockSize:])
+		if err := WriteBlock(file, sb, blk, &fb); err != nil {
+			return err
+		}
+	}
+
+	truncateInodeBlocks(file, sb, &inode, needed)
+
+	inode.I_s = int32(len(data))
+	inode.I_mtime = int32(time.Now().Unix())
+	return WriteInode(file, sb, inodeIndex, inode)
+}
+
+// IsInodeUsed consulta el bitmap de inodos
+func IsInodeUsed(file *os.File, sb structures.SuperBlock, inodeIndex int32) bool {
+	b := []byte{0}
+	file.Seek(int64(sb.S_bm_inode_start+inodeIndex), 0)
+	file.Read(b)
+	return b[0] != 0
 }
@@ -0,0 +1,128 @@
+package disk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"Proyecto/Estructuras/structures"
+)
+
+// newTestBlocks crea un archivo con sólo el bitmap de bloques y los bloques
+func newTestBlocks(t *testing.T, count int32) (*os.File, structures.SuperBlock) {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "disk.mia"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		dropBitmaps(file.Name())
+		file.Close()
+	})
+
+	sb := structures.SuperBlock{
+		S_blocks_count:   count,
+		S_bm_block_start: 0,
+		S_block_start:    count,
+		S_block_s:        64,
+	}
+	if err := file.Truncate(int64(count) + int64(count)*64); err != nil {
+		t.Fatal(err)
+	}
+	return file, sb
+}
+
+func TestInodeBlockAt(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int32
+		slot int     // posición en I_block
+		path []int32 // índices dentro de cada bloque de apuntadores
+	}{
+		{"primer directo", 0, 0, nil},
+		{"último directo", 11, 11, nil},
+		{"primer indirecto simple", 12, 12, []int32{0}},
+		{"último indirecto simple", 27, 12, []int32{15}},
+		{"primer indirecto doble", 28, 13, []int32{0, 0}},
+		{"indirecto doble intermedio", 28 + 16*3 + 5, 13, []int32{3, 5}},
+		{"último indirecto doble", 283, 13, []int32{15, 15}},
+		{"primer indirecto triple", 284, 14, []int32{0, 0, 0}},
+		{"indirecto triple intermedio", 284 + 256*2 + 16*7 + 9, 14, []int32{2, 7, 9}},
+		{"último indirecto triple", 4379, 14, []int32{15, 15, 15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, sb := newTestBlocks(t, 8)
+
+			inode := emptyInode()
+			if blk, err := inodeBlockAt(file, sb, &inode, tt.n, false); blk != -1 || err != nil {
+				t.Fatalf("sin reservar = (%d, %v), se esperaba (-1, nil)", blk, err)
+			}
+
+			blk, err := inodeBlockAt(file, sb, &inode, tt.n, true)
+			if err != nil {
+				t.Fatalf("inodeBlockAt() error: %v", err)
+			}
+
+			// Se sigue la cadena de apuntadores desde I_block
+			current := inode.I_block[tt.slot]
+			for _, index := range tt.path {
+				var ptr structures.BloqueApuntador
+				if err := ReadBlock(file, sb, current, &ptr); err != nil {
+					t.Fatal(err)
+				}
+				current = ptr.B_pointers[index]
+			}
+			if current != blk {
+				t.Errorf("la cadena de I_block[%d] lleva al bloque %d, inodeBlockAt devolvió %d", tt.slot, current, blk)
+			}
+
+			if used := usedBlocks(t, file, sb); used != int32(len(tt.path))+1 {
+				t.Errorf("se reservaron %d bloques, se esperaban %d", used, len(tt.path)+1)
+			}
+
+			if again, err := inodeBlockAt(file, sb, &inode, tt.n, false); again != blk || err != nil {
+				t.Errorf("segunda lectura = (%d, %v), se esperaba (%d, nil)", again, err, blk)
+			}
+		})
+	}
+}
+
+func TestInodeBlockAtBeyondCapacity(t *testing.T) {
+	file, sb := newTestBlocks(t, 8)
+
+	inode := emptyInode()
+	if _, err := inodeBlockAt(file, sb, &inode, 4380, true); err == nil {
+		t.Error("inodeBlockAt() más allá del indirecto triple debería fallar")
+	}
+}
+
+func usedBlocks(t *testing.T, file *os.File, sb structures.SuperBlock) int32 {
+	t.Helper()
+
+	// Las marcas pueden estar sólo en la caché
+	flushBitmaps(file)
+
+	bits := make([]byte, sb.S_blocks_count)
+	if _, err := file.ReadAt(bits, int64(sb.S_bm_block_start)); err != nil {
+		t.Fatal(err)
+	}
+
+	used := int32(0)
+	for _, b := range bits {
+		if b != 0 {
+			used++
+		}
+	}
+	return used
+}
+
+func emptyInode() structures.Inode {
+	var inode structures.Inode
+	for i := range inode.I_block {
+		inode.I_block[i] = -1
+	}
+	return inode
+}
@@ -0,0 +1,222 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"Proyecto/Estructuras/structures"
+)
+
+/* =========================
+   JOURNAL (EXT3)
+========================= */
+
+const (
+	fsExt2 int32 = 2
+	fsExt3 int32 = 3
+)
+
+var (
+	superBlockSize = int32(binary.Size(structures.SuperBlock{}))
+	journalSize    = int32(binary.Size(structures.Journal{}))
+
+	journalPathChunk    = len(structures.Information{}.I_path)
+	journalContentChunk = len(structures.Information{}.I_content)
+)
+
+// JournalEntry es una operación con sus continuaciones ya unidas
+type JournalEntry struct {
+	Operation string
+	Path      string
+	Content   string
+	Date      int32
+	Uid       int32
+	Gid       int32
+}
+
+// journalBounds devuelve la posición del journal y cuántas entradas caben:
+// ocupa desde el fin del SuperBloque hasta el bitmap de inodos
+func journalBounds(start int64, sb structures.SuperBlock) (int64, int32) {
+	first := start + int64(superBlockSize)
+	return first, int32((int64(sb.S_bm_inode_start) - first) / int64(journalSize))
+}
+
+// nextJournalSlot busca la primera entrada libre. Las entradas se escriben
+// en orden y nunca se borran, así que basta una búsqueda binaria.
+func nextJournalSlot(file *os.File, first int64, capacity int32) (int32, error) {
+	lo, hi := int32(0), capacity
+	for lo < hi {
+		mid := (lo + hi) / 2
+
+		buf := make([]byte, 4)
+		if _, err := file.ReadAt(buf, first+int64(mid*journalSize)); err != nil {
+			return 0, fmt.Errorf("❌ Error al leer el journal")
+		}
+
+		if binary.LittleEndian.Uint32(buf) != 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// appendJournal registra una operación si la partición es EXT3
+func appendJournal(file *os.File, start int64, sb structures.SuperBlock, entry JournalEntry) error {
+
+	if sb.S_filesystem_type != fsExt3 {
+		return nil
+	}
+
+	first, capacity := journalBounds(start, sb)
+
+	slot, err := nextJournalSlot(file, first, capacity)
+	if err != nil {
+		return err
+	}
+
+	raw := encodeJournal(entry, slot+1)
+	if slot+int32(len(raw)) > capacity {
+		return fmt.Errorf("❌ Error: el journal está lleno")
+	}
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, raw)
+
+	if _, err := file.WriteAt(buf.Bytes(), first+int64(slot*journalSize)); err != nil {
+		return fmt.Errorf("❌ Error al escribir el journal")
+	}
+	return nil
+}
+
+// encodeJournal reparte la operación en tantas entradas como necesiten su
+// ruta y su contenido; count es el número de la primera
+func encodeJournal(entry JournalEntry, count int32) []structures.Journal {
+
+	pieces := 1
+	for pieces*journalPathChunk < len(entry.Path) || pieces*journalContentChunk < len(entry.Content) {
+		pieces++
+	}
+
+	raw := make([]structures.Journal, pieces)
+	for i := range raw {
+		r := &raw[i]
+		r.J_count = count + int32(i)
+		if i < pieces-1 {
+			r.J_more = 1
+		}
+
+		r.J_path_s = byte(copy(r.J_content.I_path[:], chunk(entry.Path, i, journalPathChunk)))
+		r.J_content_s = byte(copy(r.J_content.I_content[:], chunk(entry.Content, i, journalContentChunk)))
+		copy(r.J_content.I_operation[:], entry.Operation)
+		r.J_content.I_date = entry.Date
+		r.J_content.I_uid = entry.Uid
+		r.J_content.I_gid = entry.Gid
+	}
+	return raw
+}
+
+// decodeJournal une cada operación con sus continuaciones. Se detiene en la
+// primera entrada libre; una operación sin su última parte se descarta.
+func decodeJournal(raw []structures.Journal) []JournalEntry {
+
+	var entries []JournalEntry
+	var current *JournalEntry
+	var pathBuf, contentBuf []byte
+
+	for _, r := range raw {
+		if r.J_count == 0 {
+			break
+		}
+
+		if current == nil {
+			current = &JournalEntry{
+				Operation: string(bytes.TrimRight(r.J_content.I_operation[:], "\x00")),
+				Date:      r.J_content.I_date,
+				Uid:       r.J_content.I_uid,
+				Gid:       r.J_content.I_gid,
+			}
+		}
+		pathBuf = append(pathBuf, r.J_content.I_path[:min(int(r.J_path_s), journalPathChunk)]...)
+		contentBuf = append(contentBuf, r.J_content.I_content[:min(int(r.J_content_s), journalContentChunk)]...)
+
+		if r.J_more == 0 {
+			current.Path = string(pathBuf)
+			current.Content = string(contentBuf)
+			entries = append(entries, *current)
+			current, pathBuf, contentBuf = nil, nil, nil
+		}
+	}
+
+	return entries
+}
+
+func chunk(s string, i int, size int) string {
+	from := i * size
+	if from >= len(s) {
+		return ""
+	}
+	return s[from:min(from+size, len(s))]
+}
+
+// ReportContent devuelve el contenido para mostrarlo en un reporte: las
+// contraseñas de los registros de users.txt se ocultan
+func (e JournalEntry) ReportContent() string {
+	if e.Path != usersFilePath {
+		return e.Content
+	}
+
+	store, err := parseUsers(e.Content)
+	if err != nil {
+		return e.Content
+	}
+	for _, r := range store.records {
+		if r.Kind == "U" {
+			r.Password = "********"
+		}
+	}
+	return strings.TrimSuffix(store.String(), "\n")
+}
+
+// recordJournal registra la operación de un comando que ya se aplicó, con el
+// usuario y grupo de la sesión para que recovery la repita con los mismos
+// permisos. Si el journal está lleno el cambio no se revierte, pero se
+// informa como error porque recovery no podrá reproducirlo.
+func recordJournal(session *Session, file *os.File, start int64, sb structures.SuperBlock, operation string, p string, content string) error {
+	entry := JournalEntry{
+		Operation: operation,
+		Path:      p,
+		Content:   content,
+		Date:      int32(time.Now().Unix()),
+		Uid:       session.Uid,
+		Gid:       session.Gid,
+	}
+	if err := appendJournal(file, start, sb, entry); err != nil {
+		return fmt.Errorf("%s; la operación '%s %s' se aplicó pero no quedó registrada", err.Error(), operation, p)
+	}
+	return nil
+}
+
+// ReadJournal devuelve las operaciones registradas en una partición EXT3
+func ReadJournal(file *os.File, start int64, sb structures.SuperBlock) ([]JournalEntry, error) {
+
+	if sb.S_filesystem_type != fsExt3 {
+		return nil, fmt.Errorf("la partición no tiene journal (no es EXT3)")
+	}
+
+	first, capacity := journalBounds(start, sb)
+
+	raw := make([]structures.Journal, capacity)
+	area := io.NewSectionReader(file, first, int64(capacity)*int64(journalSize))
+	if err := binary.Read(area, binary.LittleEndian, raw); err != nil {
+		return nil, fmt.Errorf("error al leer el journal")
+	}
+
+	return decodeJournal(raw), nil
+}
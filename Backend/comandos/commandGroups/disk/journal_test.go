@@ -0,0 +1,161 @@
+package disk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"Proyecto/Estructuras/structures"
+)
+
+// newTestJournal crea un archivo con el SuperBloque y un journal de capacity
+// entradas; el bitmap de inodos empieza justo después
+func newTestJournal(t *testing.T, capacity int32) (*os.File, structures.SuperBlock) {
+	t.Helper()
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "disk.mia"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+
+	sb := structures.SuperBlock{
+		S_filesystem_type: fsExt3,
+		S_bm_inode_start:  superBlockSize + capacity*journalSize,
+	}
+	if err := file.Truncate(int64(sb.S_bm_inode_start)); err != nil {
+		t.Fatal(err)
+	}
+	return file, sb
+}
+
+func TestJournalRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  JournalEntry
+		pieces int
+	}{
+		{
+			name:   "sin contenido",
+			entry:  JournalEntry{Operation: "mkdir", Path: "/home"},
+			pieces: 1,
+		},
+		{
+			name:   "contenido exacto de una entrada",
+			entry:  JournalEntry{Operation: "mkfile", Path: "/a.txt", Content: strings.Repeat("x", journalContentChunk)},
+			pieces: 1,
+		},
+		{
+			name:   "contenido largo",
+			entry:  JournalEntry{Operation: "edit", Path: "/a.txt", Content: strings.Repeat("0123456789", 20)},
+			pieces: 4,
+		},
+		{
+			name:   "ruta larga",
+			entry:  JournalEntry{Operation: "mkdir", Path: "/" + strings.Repeat("carpeta/", 10)},
+			pieces: 3,
+		},
+		{
+			name:   "bytes NUL al final",
+			entry:  JournalEntry{Operation: "mkfile", Path: "/bin", Content: "ab\x00\x00"},
+			pieces: 1,
+		},
+		{
+			name:   "bytes NUL en el límite de una entrada",
+			entry:  JournalEntry{Operation: "mkfile", Path: "/bin", Content: strings.Repeat("\x00", journalContentChunk+1)},
+			pieces: 2,
+		},
+		{
+			name:   "fecha, usuario y grupo",
+			entry:  JournalEntry{Operation: "chmod", Path: "/a", Content: "777", Date: 1700000000, Uid: 4, Gid: 3},
+			pieces: 1,
+		},
+	}
+
+	file, sb := newTestJournal(t, 16)
+
+	for _, tt := range tests {
+		raw := encodeJournal(tt.entry, 5)
+		if len(raw) != tt.pieces {
+			t.Errorf("%s: encodeJournal() usó %d entradas, se esperaban %d", tt.name, len(raw), tt.pieces)
+		}
+		for i, r := range raw {
+			if r.J_count != 5+int32(i) || (r.J_more == 1) != (i < len(raw)-1) {
+				t.Errorf("%s: entrada %d con J_count = %d y J_more = %d", tt.name, i, r.J_count, r.J_more)
+			}
+		}
+
+		if err := appendJournal(file, 0, sb, tt.entry); err != nil {
+			t.Fatalf("%s: appendJournal() error: %v", tt.name, err)
+		}
+	}
+
+	entries, err := ReadJournal(file, 0, sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(tests) {
+		t.Fatalf("ReadJournal() devolvió %d operaciones, se esperaban %d", len(entries), len(tests))
+	}
+
+	for i, tt := range tests {
+		if entries[i] != tt.entry {
+			t.Errorf("%s: ReadJournal() = %+v, se esperaba %+v", tt.name, entries[i], tt.entry)
+		}
+	}
+}
+
+func TestDecodeJournalIncomplete(t *testing.T) {
+	first := encodeJournal(JournalEntry{Operation: "mkdir", Path: "/a"}, 1)
+	second := encodeJournal(JournalEntry{Operation: "edit", Path: "/a.txt", Content: strings.Repeat("x", 100)}, 2)
+
+	// La segunda operación se cortó antes de escribir su última parte
+	raw := append(first, second[0])
+
+	entries := decodeJournal(raw)
+	if len(entries) != 1 || entries[0].Path != "/a" {
+		t.Errorf("decodeJournal() = %+v, se esperaba solo la primera operación", entries)
+	}
+}
+
+func TestJournalFull(t *testing.T) {
+	file, sb := newTestJournal(t, 2)
+
+	// Una operación que necesita tres entradas no cabe
+	long := JournalEntry{Operation: "edit", Path: "/a.txt", Content: strings.Repeat("x", 3*journalContentChunk)}
+	if err := appendJournal(file, 0, sb, long); err == nil {
+		t.Fatal("appendJournal() debería fallar con el journal lleno")
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := appendJournal(file, 0, sb, JournalEntry{Operation: "mkdir", Path: "/a"}); err != nil {
+			t.Fatalf("appendJournal() %d error: %v", i, err)
+		}
+	}
+	if err := appendJournal(file, 0, sb, JournalEntry{Operation: "mkdir", Path: "/b"}); err == nil {
+		t.Error("appendJournal() debería fallar con el journal lleno")
+	}
+
+	if entries, _ := ReadJournal(file, 0, sb); len(entries) != 2 {
+		t.Errorf("ReadJournal() devolvió %d operaciones, se esperaban 2", len(entries))
+	}
+}
+
+func TestJournalReportContent(t *testing.T) {
+	tests := []struct {
+		entry JournalEntry
+		want  string
+	}{
+		{JournalEntry{Path: usersFilePath, Content: "4,U,devs,ana,sha256$ab$cd"}, "4,U,devs,ana,********"},
+		{JournalEntry{Path: usersFilePath, Content: "3,G,devs"}, "3,G,devs"},
+		{JournalEntry{Path: usersFilePath, Content: "ana,devs"}, "ana,devs"},
+		{JournalEntry{Path: "/notas.txt", Content: "1,U,root,root,123"}, "1,U,root,root,123"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.entry.ReportContent(); got != tt.want {
+			t.Errorf("ReportContent(%q) = %q, se esperaba %q", tt.entry.Content, got, tt.want)
+		}
+	}
+}
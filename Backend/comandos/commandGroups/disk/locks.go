@@ -0,0 +1,88 @@
+package disk
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+/* =========================
+   CANDADOS POR DISCO
+========================= */
+
+// Cada .mia tiene su propio RWMutex: los comandos de solo lectura (cat,
+// find, reportes) pueden ir en paralelo, los que modifican son exclusivos.
+//
+// Orden de adquisición para evitar interbloqueos:
+// disco -> mountedMu -> sessionsMu
+var (
+	diskLocksMu sync.Mutex
+	diskLocks   = map[string]*sync.RWMutex{}
+)
+
+// diskKey normaliza la ruta del .mia para que "./x.mia" y la ruta absoluta
+// compartan candado y caché
+func diskKey(path string) string {
+	key := filepath.Clean(path)
+	if abs, err := filepath.Abs(key); err == nil {
+		key = abs
+	}
+	return key
+}
+
+func diskLock(path string) *sync.RWMutex {
+	key := diskKey(path)
+
+	diskLocksMu.Lock()
+	defer diskLocksMu.Unlock()
+
+	lock, ok := diskLocks[key]
+	if !ok {
+		lock = &sync.RWMutex{}
+		diskLocks[key] = lock
+	}
+	return lock
+}
+
+// LockDisk bloquea el disco para escritura y devuelve la función que lo libera
+func LockDisk(path string) func() {
+	lock := diskLock(path)
+	lock.Lock()
+	return lock.Unlock
+}
+
+// RLockDisk bloquea el disco para lectura y devuelve la función que lo libera
+func RLockDisk(path string) func() {
+	lock := diskLock(path)
+	lock.RLock()
+	return lock.RUnlock
+}
+
+// openDisk abre el .mia tomando su candado; release cierra el archivo y
+// libera el candado. En modo escritura, antes de cerrar vuelca al disco los
+// bitmaps modificados en memoria.
+func openDisk(path string, write bool) (*os.File, func(), error) {
+
+	unlock := RLockDisk
+	flag := os.O_RDONLY
+	if write {
+		unlock = LockDisk
+		flag = os.O_RDWR
+	}
+
+	release := unlock(path)
+
+	file, err := os.OpenFile(path, flag, 0666)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+
+	return file, func() {
+		if write {
+			flushBitmaps(file)
+		}
+		file.Close()
+		release()
+	}, nil
+}
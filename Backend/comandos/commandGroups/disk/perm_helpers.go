@@ -0,0 +1,113 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"fmt"
+	"os"
+)
+
+// PERMISOS UGO
+const (
+	permRead  byte = 4
+	permWrite byte = 2
+	permExec  byte = 1
+)
+
+// hasPermission compara la sesión con el propietario, el grupo y los bits
+// UGO del inodo. root siempre tiene acceso.
+func hasPermission(session *Session, inode structures.Inode, perm byte) bool {
+	if session == nil {
+		return false
+	}
+	if session.User == "root" {
+		return true
+	}
+
+	var bits byte
+	switch {
+	case inode.I_uid == session.Uid:
+		bits = inode.I_perm[0]
+	case inode.I_gid == session.Gid:
+		bits = inode.I_perm[1]
+	default:
+		bits = inode.I_perm[2]
+	}
+
+	return bits&perm == perm
+}
+
+// checkPermission es la validación común de los comandos de archivos:
+// devuelve un error con el permiso faltante sobre p
+func checkPermission(session *Session, inode structures.Inode, perm byte, p string) error {
+	if hasPermission(session, inode, perm) {
+		return nil
+	}
+
+	name := "ejecución"
+	switch perm {
+	case permRead:
+		name = "lectura"
+	case permWrite:
+		name = "escritura"
+	}
+
+	return fmt.Errorf("sin permiso de %s en '%s'", name, p)
+}
+
+// checkInodePermission lee el inodo y aplica checkPermission
+func checkInodePermission(session *Session, file *os.File, sb structures.SuperBlock, inodeIndex int32, perm byte, p string) error {
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return err
+	}
+	return checkPermission(session, inode, perm, p)
+}
+
+// isOwner indica si la sesión puede cambiar permisos o propietario del inodo
+func isOwner(session *Session, inode structures.Inode) bool {
+	if session == nil {
+		return false
+	}
+	return session.User == "root" || inode.I_uid == session.Uid
+}
+
+// applyOwnedTree aplica fn al inodo (y a su subárbol si recursive) en los
+// inodos donde la sesión es propietaria. Devuelve cuántos se omitieron.
+func applyOwnedTree(
+	session *Session,
+	file *os.File,
+	sb structures.SuperBlock,
+	inodeIndex int32,
+	recursive bool,
+	fn func(*structures.Inode),
+) (int, error) {
+
+	inode, err := ReadInode(file, sb, inodeIndex)
+	if err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	if isOwner(session, inode) {
+		fn(&inode)
+		if err := WriteInode(file, sb, inodeIndex, inode); err != nil {
+			return skipped, err
+		}
+	} else {
+		skipped++
+	}
+
+	if !recursive || inode.I_type != 0 {
+		return skipped, nil
+	}
+
+	for _, entry := range directoryEntries(file, sb, inode) {
+		n, err := applyOwnedTree(session, file, sb, entry.B_inodo, recursive, fn)
+		skipped += n
+		if err != nil {
+			return skipped, err
+		}
+	}
+
+	return skipped, nil
+}
@@ -0,0 +1,317 @@
+package disk
+
+import (
+	"Proyecto/Estructuras/structures"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/* =========================
+   USERS.TXT
+========================= */
+
+// users.txt siempre ocupa el inodo 1
+const usersInodeIndex int32 = 1
+
+const usersFilePath = "/users.txt"
+
+// Longitud máxima de nombres y contraseñas en users.txt
+const usersFieldMax = 10
+
+// usersRecord es una línea de users.txt:
+//
+//	GID,G,grupo
+//	UID,U,grupo,usuario,contraseña
+//
+// La contraseña se guarda con hashPassword. Un ID 0 marca el registro como eliminado.
+type usersRecord struct {
+	ID       int32
+	Kind     string
+	Group    string
+	Name     string
+	Password string
+}
+
+func (r *usersRecord) active() bool {
+	return r.ID != 0
+}
+
+func (r *usersRecord) String() string {
+	if r.Kind == "G" {
+		return fmt.Sprintf("%d,G,%s", r.ID, r.Group)
+	}
+	return fmt.Sprintf("%d,U,%s,%s,%s", r.ID, r.Group, r.Name, r.Password)
+}
+
+// usersStore mantiene los registros en el orden del archivo
+type usersStore struct {
+	records []*usersRecord
+}
+
+// loadUsersStore lee y valida users.txt de la partición
+func loadUsersStore(file *os.File, sb structures.SuperBlock) (*usersStore, error) {
+
+	inode, err := ReadInode(file, sb, usersInodeIndex)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Error al leer el inodo de users.txt")
+	}
+
+	data, err := readInodeData(file, sb, inode)
+	if err != nil {
+		return nil, fmt.Errorf("❌ Error al leer users.txt")
+	}
+
+	return parseUsers(string(data))
+}
+
+func parseUsers(content string) (*usersStore, error) {
+
+	store := &usersStore{}
+
+	for n, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		id, err := strconv.Atoi(fields[0])
+		if err != nil || id < 0 || len(fields) < 2 {
+			return nil, fmt.Errorf("❌ Error: users.txt inválido en la línea %d", n+1)
+		}
+
+		record := &usersRecord{ID: int32(id), Kind: fields[1]}
+
+		switch {
+		case record.Kind == "G" && len(fields) == 3:
+			record.Group = fields[2]
+		case record.Kind == "U" && len(fields) == 5:
+			record.Group = fields[2]
+			record.Name = fields[3]
+			record.Password = fields[4]
+		default:
+			return nil, fmt.Errorf("❌ Error: users.txt inválido en la línea %d", n+1)
+		}
+
+		store.records = append(store.records, record)
+	}
+
+	return store, nil
+}
+
+func (s *usersStore) String() string {
+	var b strings.Builder
+	for _, r := range s.records {
+		b.WriteString(r.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// save escribe users.txt por la ruta normal de inodos (crece o se reduce
+// según haga falta) y actualiza los contadores del SuperBloque
+func (s *usersStore) save(file *os.File, start int64, sb *structures.SuperBlock) error {
+
+	if err := writeInodeData(file, *sb, usersInodeIndex, []byte(s.String())); err != nil {
+		return err
+	}
+
+	return commitSuperBlock(file, start, sb)
+}
+
+// findUser devuelve el usuario activo con ese nombre
+func (s *usersStore) findUser(name string) *usersRecord {
+	for _, r := range s.records {
+		if r.Kind == "U" && r.active() && r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// findGroup devuelve el grupo activo con ese nombre
+func (s *usersStore) findGroup(name string) *usersRecord {
+	for _, r := range s.records {
+		if r.Kind == "G" && r.active() && r.Group == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// findByID devuelve el usuario ("U") o grupo ("G") activo con ese ID
+func (s *usersStore) findByID(kind string, id int32) *usersRecord {
+	for _, r := range s.records {
+		if r.Kind == kind && r.active() && r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// nextID numera grupos y usuarios por separado. Nunca reutiliza el ID de
+// un registro eliminado: las líneas con ID 0 conservan su posición, así que
+// también se cuentan.
+func (s *usersStore) nextID(kind string) int32 {
+	count, maxID := int32(0), int32(0)
+	for _, r := range s.records {
+		if r.Kind != kind {
+			continue
+		}
+		count++
+		if r.ID > maxID {
+			maxID = r.ID
+		}
+	}
+	return max(count, maxID) + 1
+}
+
+func validateUsersField(field string, value string) error {
+	if value == "" {
+		return fmt.Errorf("❌ Error: el parámetro %s es obligatorio", field)
+	}
+	if len(value) > usersFieldMax {
+		return fmt.Errorf("❌ Error: %s excede %d caracteres", field, usersFieldMax)
+	}
+	if strings.ContainsAny(value, ",\n") {
+		return fmt.Errorf("❌ Error: %s no puede contener comas ni saltos de línea", field)
+	}
+	return nil
+}
+
+func (s *usersStore) addGroup(name string) (*usersRecord, error) {
+	if err := validateUsersField("name", name); err != nil {
+		return nil, err
+	}
+	if s.findGroup(name) != nil {
+		return nil, fmt.Errorf("❌ Error: el grupo ya existe")
+	}
+
+	record := &usersRecord{ID: s.nextID("G"), Kind: "G", Group: name}
+	s.records = append(s.records, record)
+	return record, nil
+}
+
+func (s *usersStore) addUser(name string, password string, group string) (*usersRecord, error) {
+	fields := [][2]string{{"user", name}, {"pass", password}, {"grp", group}}
+	for _, f := range fields {
+		if err := validateUsersField(f[0], f[1]); err != nil {
+			return nil, err
+		}
+	}
+	if s.findUser(name) != nil {
+		return nil, fmt.Errorf("❌ Error: el usuario ya existe")
+	}
+	if s.findGroup(group) == nil {
+		return nil, fmt.Errorf("❌ Error: el grupo indicado no existe")
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &usersRecord{ID: s.nextID("U"), Kind: "U", Group: group, Name: name, Password: hash}
+	s.records = append(s.records, record)
+	return record, nil
+}
+
+// removeUser marca el usuario con ID 0; root está protegido
+func (s *usersStore) removeUser(name string) error {
+	if name == "root" {
+		return fmt.Errorf("❌ Error: el usuario root no puede eliminarse")
+	}
+
+	user := s.findUser(name)
+	if user == nil {
+		return fmt.Errorf("❌ Error: el usuario '%s' no existe", name)
+	}
+
+	user.ID = 0
+	return nil
+}
+
+// removeGroup marca el grupo con ID 0 si ya no tiene usuarios activos
+func (s *usersStore) removeGroup(name string) error {
+	if name == "root" {
+		return fmt.Errorf("❌ Error: el grupo root no puede eliminarse")
+	}
+
+	group := s.findGroup(name)
+	if group == nil {
+		return fmt.Errorf("❌ Error: el grupo '%s' no existe", name)
+	}
+
+	for _, r := range s.records {
+		if r.Kind == "U" && r.active() && r.Group == name {
+			return fmt.Errorf("❌ Error: el grupo '%s' aún tiene usuarios activos", name)
+		}
+	}
+
+	group.ID = 0
+	return nil
+}
+
+// setUserGroup cambia el grupo de un usuario activo
+func (s *usersStore) setUserGroup(name string, group string) error {
+	if s.findGroup(group) == nil {
+		return fmt.Errorf("❌ Error: el grupo '%s' no existe", group)
+	}
+
+	user := s.findUser(name)
+	if user == nil {
+		return fmt.Errorf("❌ Error: el usuario '%s' no existe", name)
+	}
+
+	user.Group = group
+	return nil
+}
+
+/* =========================
+   CONTRASEÑAS
+========================= */
+
+// Las contraseñas se guardan como sha256$<sal>$<hash>, ambos en hexadecimal
+const passwordHashScheme = "sha256"
+
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("❌ Error al generar la sal de la contraseña")
+	}
+
+	return passwordHashScheme + "$" + hex.EncodeToString(salt) + "$" + passwordDigest(salt, password), nil
+}
+
+func passwordDigest(salt []byte, password string) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), password...))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyPassword compara la contraseña con el valor guardado. legacy indica
+// que el valor está en texto plano y debe migrarse.
+func verifyPassword(stored string, password string) (ok bool, legacy bool) {
+
+	parts := strings.Split(stored, "$")
+	if len(parts) != 3 || parts[0] != passwordHashScheme {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1, true
+	}
+
+	salt, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return false, false
+	}
+
+	digest := passwordDigest(salt, password)
+	return subtle.ConstantTimeCompare([]byte(digest), []byte(parts[2])) == 1, false
+}
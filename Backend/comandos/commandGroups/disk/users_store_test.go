@@ -0,0 +1,160 @@
+package disk
+
+import "testing"
+
+func TestParseUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string // store.String() esperado
+		wantErr bool
+	}{
+		{
+			name:    "archivo inicial",
+			content: "1,G,root\n1,U,root,root,123\n",
+			want:    "1,G,root\n1,U,root,root,123\n",
+		},
+		{
+			name:    "espacios y líneas vacías",
+			content: "\n 1 , G , root \n\n2,U, root ,ana,x\n",
+			want:    "1,G,root\n2,U,root,ana,x\n",
+		},
+		{
+			name:    "registros eliminados",
+			content: "1,G,root\n0,G,devs\n0,U,devs,ana,x\n",
+			want:    "1,G,root\n0,G,devs\n0,U,devs,ana,x\n",
+		},
+		{name: "vacío", content: "", want: ""},
+		{name: "ID no numérico", content: "a,G,root\n", wantErr: true},
+		{name: "ID negativo", content: "-1,G,root\n", wantErr: true},
+		{name: "tipo desconocido", content: "1,X,root\n", wantErr: true},
+		{name: "grupo con campos de más", content: "1,G,root,extra\n", wantErr: true},
+		{name: "usuario incompleto", content: "1,U,root,root\n", wantErr: true},
+		{name: "solo ID", content: "1\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := parseUsers(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseUsers(%q) debería fallar", tt.content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseUsers(%q) error: %v", tt.content, err)
+			}
+			if got := store.String(); got != tt.want {
+				t.Errorf("parseUsers(%q).String() = %q, se esperaba %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUsersField(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"ana", false},
+		{"1234567890", false},
+		{"", true},
+		{"12345678901", true},
+		{"a,b", true},
+		{"a\nb", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateUsersField("user", tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("validateUsersField(%q) error = %v, se esperaba error: %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestUsersStoreOperations(t *testing.T) {
+	store, err := parseUsers("1,G,root\n1,U,root,root,123\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name    string
+		apply   func() error
+		wantErr bool
+	}{
+		{"mkgrp devs", func() error { _, err := store.addGroup("devs"); return err }, false},
+		{"mkgrp repetido", func() error { _, err := store.addGroup("devs"); return err }, true},
+		{"mkusr ana", func() error { _, err := store.addUser("ana", "123", "devs"); return err }, false},
+		{"mkusr repetido", func() error { _, err := store.addUser("ana", "123", "devs"); return err }, true},
+		{"mkusr sin grupo", func() error { _, err := store.addUser("bob", "123", "ops"); return err }, true},
+		{"rmgrp con usuarios", func() error { return store.removeGroup("devs") }, true},
+		{"rmgrp root", func() error { return store.removeGroup("root") }, true},
+		{"rmusr root", func() error { return store.removeUser("root") }, true},
+		{"chgrp a root", func() error { return store.setUserGroup("ana", "root") }, false},
+		{"chgrp a grupo inexistente", func() error { return store.setUserGroup("ana", "ops") }, true},
+		{"rmgrp devs", func() error { return store.removeGroup("devs") }, false},
+		{"rmusr ana", func() error { return store.removeUser("ana") }, false},
+		{"rmusr inexistente", func() error { return store.removeUser("ana") }, true},
+		// Los IDs de registros eliminados no se reutilizan
+		{"mkgrp ops", func() error { _, err := store.addGroup("ops"); return err }, false},
+	}
+
+	for _, step := range steps {
+		if err := step.apply(); (err != nil) != step.wantErr {
+			t.Fatalf("%s: error = %v, se esperaba error: %v", step.name, err, step.wantErr)
+		}
+	}
+
+	want := []struct {
+		id    int32
+		kind  string
+		group string
+		name  string
+	}{
+		{1, "G", "root", ""},
+		{1, "U", "root", "root"},
+		{0, "G", "devs", ""},
+		{0, "U", "root", "ana"},
+		{3, "G", "ops", ""},
+	}
+
+	if len(store.records) != len(want) {
+		t.Fatalf("hay %d registros, se esperaban %d:\n%s", len(store.records), len(want), store.String())
+	}
+	for i, w := range want {
+		r := store.records[i]
+		if r.ID != w.id || r.Kind != w.kind || r.Group != w.group || r.Name != w.name {
+			t.Errorf("registro %d = %q, se esperaba %d,%s,%s,%s", i, r.String(), w.id, w.kind, w.group, w.name)
+		}
+	}
+
+	if store.findUser("ana") != nil || store.findGroup("devs") != nil {
+		t.Error("findUser y findGroup no deberían devolver registros eliminados")
+	}
+}
+
+func TestUsersStoreNextID(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		kind    string
+		want    int32
+	}{
+		{"primer grupo después de root", "1,G,root\n1,U,root,root,123\n", "G", 2},
+		{"primer usuario después de root", "1,G,root\n1,U,root,root,123\n", "U", 2},
+		{"no reutiliza grupos eliminados", "1,G,root\n1,U,root,root,123\n0,G,devs\n", "G", 3},
+		{"los grupos no cuentan para usuarios", "1,G,root\n1,U,root,root,123\n2,G,devs\n3,G,ops\n", "U", 2},
+		{"respeta un ID mayor", "1,G,root\n7,G,devs\n", "G", 8},
+	}
+
+	for _, tt := range tests {
+		store, err := parseUsers(tt.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := store.nextID(tt.kind); got != tt.want {
+			t.Errorf("%s: nextID(%s) = %d, se esperaba %d", tt.name, tt.kind, got, tt.want)
+		}
+	}
+}
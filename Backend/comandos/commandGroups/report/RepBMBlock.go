@@ -18,6 +18,8 @@ func RepBMBlock(id string, fileName string) (string, bool) {
 		return "ID de partición no encontrado", true
 	}
 
+	defer disk.RLockDisk(mount.Path)()
+
 	file, err := os.Open(mount.Path)
 	if err != nil {
 		return "No se pudo abrir el disco", true
@@ -44,7 +46,8 @@ func RepBMBlock(id string, fileName string) (string, bool) {
 	}
 	defer txt.Close()
 
-	fmt.Fprintln(txt, "# Bitmap de Bloques\n")
+	fmt.Fprintln(txt, "# Bitmap de Bloques")
+	fmt.Fprintln(txt)
 
 	count := 0
 	for i := int32(0); i < sb.S_blocks_count; i++ {
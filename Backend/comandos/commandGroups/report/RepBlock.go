@@ -19,6 +19,8 @@ func RepBlock(id string, fileName string) (string, bool) {
 		return "ID de partición no encontrado", true
 	}
 
+	defer disk.RLockDisk(mount.Path)()
+
 	file, err := os.Open(mount.Path)
 	if err != nil {
 		return "No se pudo abrir el disco", true
@@ -50,26 +52,46 @@ func RepBlock(id string, fileName string) (string, bool) {
 
 	for i := int32(0); i < sb.S_inodes_count; i++ {
 
-		inode, err := disk.ReadInode(file, sb, i)
-		if err != nil {
+		// Solo inodos usados
+		if !disk.IsInodeUsed(file, sb, i) {
 			continue
 		}
 
-		// Solo inodos usados
-		if inode.I_type == 0xFF {
+		inode, err := disk.ReadInode(file, sb, i)
+		if err != nil {
 			continue
 		}
 
-		for _, blk := range inode.I_block {
+		disk.WalkInodeBlocks(file, sb, inode, func(blk int32, level int) {
+
+			if level > 0 {
+				var pointerBlock structures.BloqueApuntador
+				if err := disk.ReadBlock(file, sb, blk, &pointerBlock); err != nil {
+					return
+				}
+
+				fmt.Fprintf(html, "<h2>Bloque Apuntadores %d (nivel %d)</h2>", blk, level)
+				fmt.Fprintln(html, "<p>")
+
+				first := true
+				for _, p := range pointerBlock.B_pointers {
+					if p != -1 {
+						if !first {
+							fmt.Fprint(html, ", ")
+						}
+						fmt.Fprint(html, p)
+						first = false
+					}
+				}
 
-			if blk == -1 {
-				continue
+				fmt.Fprintln(html, "</p>")
+				return
 			}
 
 			if inode.I_type == 0 {
 				var folder structures.BloqueCarpeta
 				if err := disk.ReadBlock(file, sb, blk, &folder); err != nil {
-					continue
+					return
 				}
 
 				fmt.Fprintf(html, "<h2>Bloque Carpeta %d</h2>", blk)
@@ -93,7 +115,7 @@ func RepBlock(id string, fileName string) (string, bool) {
 			if inode.I_type == 1 {
 				var fileBlock structures.BloqueArchivo
 				if err := disk.ReadBlock(file, sb, blk, &fileBlock); err != nil {
-					continue
+					return
 				}
 
 				fmt.Fprintf(html, "<h2>Bloque Archivo %d</h2>", blk)
@@ -101,28 +123,7 @@ func RepBlock(id string, fileName string) (string, bool) {
 				fmt.Fprintln(html, utils.ConvertirByteAString(fileBlock.B_content[:]))
 				fmt.Fprintln(html, "</pre>")
 			}
-
-			if inode.I_type == 2 {
-				var pointerBlock structures.BloqueApuntador
-				if err := disk.ReadBlock(file, sb, blk, &pointerBlock); err != nil {
-					continue
-				}
-
-				fmt.Fprintf(html, "<h2>Bloque Apuntadores %d</h2>", blk)
-				fmt.Fprintln(html, "<p>")
-
-				for i, p := range pointerBlock.B_pointers {
-					if p != -1 {
-						if i > 0 {
-							fmt.Fprint(html, ", ")
-						}
-						fmt.Fprint(html, p)
-					}
-				}
-
-				fmt.Fprintln(html, "</p>")
-			}
-		}
+		})
 	}
 
 	fmt.Fprintln(html, "</body></html>")
@@ -17,6 +17,8 @@ func RepDISK(id string, fileName string) (string, bool) {
 		return "ID de partición no encontrado", true
 	}
 
+	defer disk.RLockDisk(mount.Path)()
+
 	mbr, err, msg := utils.ObtenerEstructuraMBR(mount.Path)
 	if err {
 		return msg, true
@@ -71,11 +73,34 @@ func RepDISK(id string, fileName string) (string, bool) {
 			usado += libre
 		}
 
-		// Partición primaria
 		partPercent := (float64(p.Part_s) / totalDisk) * 100
-		fmt.Fprintf(html,
-			"<div style='width:%f%%; border-right:1px solid black; text-align:center;'>Primaria<br/>%.2f%%</div>",
-			partPercent, partPercent)
+
+		if p.Part_type == 'E' {
+			fmt.Fprintf(html,
+				"<div style='width:%f%%; border-right:1px solid black; text-align:center;'>Extendida<br/>%.2f%%",
+				partPercent, partPercent)
+
+			logicas, err, msg := utils.ObtenerListaEBR(mount.Path, p)
+			if err {
+				return msg, true
+			}
+
+			fmt.Fprintln(html, "<div style='display:flex; width:100%; border-top:1px solid black;'>")
+			for _, l := range logicas {
+				if l.EBR.Part_s <= 0 {
+					continue
+				}
+				logicaPercent := (float64(l.EBR.Part_s) / totalDisk) * 100
+				fmt.Fprintf(html,
+					"<div style='flex:1; border-right:1px solid black; text-align:center;'>EBR<br/>Lógica<br/>%.2f%%</div>",
+					logicaPercent)
+			}
+			fmt.Fprintln(html, "</div></div>")
+		} else {
+			fmt.Fprintf(html,
+				"<div style='width:%f%%; border-right:1px solid black; text-align:center;'>Primaria<br/>%.2f%%</div>",
+				partPercent, partPercent)
+		}
 
 		usado += p.Part_s
 	}
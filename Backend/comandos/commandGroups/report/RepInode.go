@@ -19,6 +19,8 @@ func RepInode(id string, fileName string) (string, bool) {
 		return "ID de partición no encontrado", true
 	}
 
+	defer disk.RLockDisk(mount.Path)()
+
 	file, err := os.OpenFile(mount.Path, os.O_RDWR, 0666)
 	if err != nil {
 		return "No se pudo abrir el disco", true
@@ -50,13 +52,13 @@ func RepInode(id string, fileName string) (string, bool) {
 
 	for i := int32(0); i < sb.S_inodes_count; i++ {
 
-		inode, err := disk.ReadInode(file, sb, i)
-		if err != nil {
+		// Solo inodos usados
+		if !disk.IsInodeUsed(file, sb, i) {
 			continue
 		}
 
-		// Solo inodos usados
-		if inode.I_type != 0 && inode.I_type != 1 {
+		inode, err := disk.ReadInode(file, sb, i)
+		if err != nil {
 			continue
 		}
 
@@ -71,12 +73,22 @@ func RepInode(id string, fileName string) (string, bool) {
 			utils.IntFechaToStr(inode.I_atime),
 		)
 
-		// Bloques directos
+		// Bloques directos (1-12) e indirectos simple, doble y triple (13-15)
 		for j, blk := range inode.I_block {
+			label := fmt.Sprintf("i_block_%d", j+1)
+			switch j {
+			case 12:
+				label += " (simple)"
+			case 13:
+				label += " (doble)"
+			case 14:
+				label += " (triple)"
+			}
+
 			fmt.Fprintf(
 				html,
-				"<tr><td>i_block_%d</td><td>%d</td></tr>",
-				j+1,
+				"<tr><td>%s</td><td>%d</td></tr>",
+				label,
 				blk,
 			)
 		}
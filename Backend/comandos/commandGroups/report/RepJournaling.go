@@ -0,0 +1,75 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"Proyecto/Estructuras/structures"
+	"Proyecto/comandos/commandGroups/disk"
+	"Proyecto/comandos/utils"
+)
+
+// RepJournaling lista las operaciones registradas en el journal de un EXT3
+func RepJournaling(id string, fileName string) (string, bool) {
+
+	mount := disk.GetMountedPartition(id)
+	if mount == nil {
+		return "ID de partición no encontrado", true
+	}
+
+	defer disk.RLockDisk(mount.Path)()
+
+	file, err := os.Open(mount.Path)
+	if err != nil {
+		return "No se pudo abrir el disco", true
+	}
+	defer file.Close()
+
+	var sb structures.SuperBlock
+	if err := disk.ReadSuperBlock(file, int64(mount.Start), &sb); err != nil {
+		return "No se pudo leer el SuperBloque", true
+	}
+
+	entries, err := disk.ReadJournal(file, int64(mount.Start), sb)
+	if err != nil {
+		return "[REP JOURNALING]: " + err.Error(), true
+	}
+
+	if !strings.HasSuffix(strings.ToLower(fileName), ".html") {
+		fileName += ".html"
+	}
+
+	reportDir := "C:/Users/Rafael Barrios/Downloads/Rep"
+	_ = os.MkdirAll(reportDir, os.ModePerm)
+
+	reportPath := filepath.Join(reportDir, fileName)
+
+	html, err := os.Create(reportPath)
+	if err != nil {
+		return "No se pudo crear el reporte", true
+	}
+	defer html.Close()
+
+	fmt.Fprintln(html, "<html><body>")
+	fmt.Fprintln(html, "<h1>Reporte de Journaling</h1>")
+	fmt.Fprintln(html, "<table border='1'>")
+	fmt.Fprintln(html, "<tr><th>#</th><th>Operación</th><th>Ruta</th><th>Contenido</th><th>Fecha</th></tr>")
+
+	for i, entry := range entries {
+		fmt.Fprintf(html, "<tr><td>%d</td><td>%s</td><td>%s</td><td><pre>%s</pre></td><td>%s</td></tr>\n",
+			i+1,
+			template.HTMLEscapeString(entry.Operation),
+			template.HTMLEscapeString(entry.Path),
+			template.HTMLEscapeString(entry.ReportContent()),
+			utils.IntFechaToStr(entry.Date),
+		)
+	}
+
+	fmt.Fprintln(html, "</table>")
+	fmt.Fprintln(html, "</body></html>")
+
+	return fmt.Sprintf("[REP JOURNALING]: Reporte generado correctamente (%d operaciones)", len(entries)), false
+}
@@ -18,6 +18,8 @@ func RepMBR(id string, fileName string) (string, bool) {
 		return "ID de partición no encontrado", true
 	}
 
+	defer disk.RLockDisk(mount.Path)()
+
 	mbr, err, msg := utils.ObtenerEstructuraMBR(mount.Path)
 	if err {
 		return msg, true
@@ -71,6 +73,31 @@ func RepMBR(id string, fileName string) (string, bool) {
 	}
 
 	fmt.Fprintln(html, "</table>")
+
+	// Particiones lógicas (cadena de EBR)
+	if extendida, existe := utils.ObtenerParticionExtendida(mbr); existe {
+		logicas, err, msg := utils.ObtenerListaEBR(mount.Path, extendida)
+		if err {
+			return msg, true
+		}
+
+		for _, l := range logicas {
+			if l.EBR.Part_s <= 0 {
+				continue
+			}
+
+			fmt.Fprintf(html, "<h2>EBR (%d)</h2>", l.Posicion)
+			fmt.Fprintln(html, "<table border='1'>")
+			fmt.Fprintf(html, "<tr><td>Mount</td><td>%d</td></tr>", l.EBR.Part_mount)
+			fmt.Fprintf(html, "<tr><td>Fit</td><td>%c</td></tr>", l.EBR.Part_fit)
+			fmt.Fprintf(html, "<tr><td>Start</td><td>%d</td></tr>", l.EBR.Part_start)
+			fmt.Fprintf(html, "<tr><td>Size</td><td>%d</td></tr>", l.EBR.Part_s)
+			fmt.Fprintf(html, "<tr><td>Next</td><td>%d</td></tr>", l.EBR.Part_next)
+			fmt.Fprintf(html, "<tr><td>Name</td><td>%s</td></tr>", utils.ConvertirByteAString(l.EBR.Name[:]))
+			fmt.Fprintln(html, "</table>")
+		}
+	}
+
 	fmt.Fprintln(html, "</body></html>")
 
 	return "[REP MBR]: Reporte generado correctamente", false
@@ -76,6 +76,13 @@ func Rep(params map[string]string) Result {
 			Error:   err,
 		}
 
+	case "journaling":
+		msg, err := RepJournaling(id, nameReport)
+		return Result{
+			Mensaje: msg,
+			Error:   err,
+		}
+
 	default:
 		return Result{
 			Mensaje: "Error: tipo de reporte no válido",
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"Proyecto/comandos/commandGroups/disk"
 	"Proyecto/comandos/general"
 	"encoding/json"
 	"fmt"
@@ -13,7 +14,7 @@ func HandleCommand(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodOptions {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, "+SessionHeader)
 		w.WriteHeader(http.StatusOK)
 		return
 	}
@@ -25,7 +26,7 @@ func HandleCommand(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "POST")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, "+SessionHeader)
 	w.Header().Set("Content-Type", "application/json")
 
 	var requestBody struct {
@@ -54,7 +55,10 @@ func HandleCommand(w http.ResponseWriter, r *http.Request) {
 	comandos := strings.Split(*requestBody.Comandos, "\n")
 	resultado := general.ExecuteCommandList(comandos)
 
-	_, contadorErrores, logs := general.GlobalCom(resultado.Salida.LstComandos)
+	// Cada cliente usa su propia sesión
+	ctx := disk.NewCommandContext(sessionToken(r))
+
+	_, contadorErrores, logs := general.GlobalCom(resultado.Salida.LstComandos, ctx)
 
 	// LOG EN CONSOLA
 	for _, r := range logs {
@@ -80,12 +84,47 @@ func HandleCommand(w http.ResponseWriter, r *http.Request) {
 		message = "Ocurrieron errores al ejecutar los comandos"
 	}
 
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookie,
+		Value:    ctx.Token(),
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   sessionCookieMaxAge(ctx.Token()),
+	})
+
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(
-		general.ResultadoSalida(
+		general.ResultadoSalidaSesion(
 			message,
 			hayError,
 			logs,
+			ctx.Token(),
 		),
 	)
 }
+
+// SESIÓN DEL CLIENTE
+const (
+	SessionHeader = "X-Session-Token"
+	SessionCookie = "mia_session"
+)
+
+// sessionToken toma el token del encabezado o, si no viene, de la cookie
+func sessionToken(r *http.Request) string {
+	if token := strings.TrimSpace(r.Header.Get(SessionHeader)); token != "" {
+		return token
+	}
+	if cookie, err := r.Cookie(SessionCookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// Sin sesión la cookie se elimina
+func sessionCookieMaxAge(token string) int {
+	if token == "" {
+		return -1
+	}
+	return 0
+}
@@ -10,12 +10,12 @@ import (
 )
 
 var commandGroups = map[string][]string{
-	"disk":    {"mkdisk", "fdisk", "rmdisk", "mount", "mounted", "mkfs"},
+	"disk":    {"mkdisk", "fdisk", "rmdisk", "mount", "unmount", "mounted", "mkfs", "loss", "recovery"},
 	"reports": {"rep"},
-	"files":   {"mkfile", "mkdir"},
+	"files":   {"mkfile", "mkdir", "remove", "edit", "rename", "copy", "move", "find", "chmod", "chown"},
 	"cat":     {"cat"},
 	"users":   {"login", "logout"},
-	"groups":  {"mkgrp", "mkusr"},
+	"groups":  {"mkgrp", "mkusr", "chgrp", "rmusr", "rmgrp"},
 }
 
 func detectGroup(cmd string) (string, string, bool, string) {
@@ -50,7 +50,7 @@ func parametrosToMap(params []string) map[string]string {
 	return m
 }
 
-func GlobalCom(lista []string) ([]string, int, []string) {
+func GlobalCom(lista []string, ctx *disk.CommandContext) ([]string, int, []string) {
 
 	var errores []string
 	var frontendLogs []string
@@ -81,7 +81,7 @@ func GlobalCom(lista []string) ([]string, int, []string) {
 		case "disk":
 			color.Cyan("Administración de discos: %s", command)
 
-			msg, err := disk.DiskExecuteCommanWithProps(command, parametros)
+			msg, err := disk.DiskExecuteCommanWithProps(ctx, command, parametros)
 			if err {
 				msgError := "[ERROR] " + msg
 				color.Red(msgError)
@@ -97,7 +97,7 @@ func GlobalCom(lista []string) ([]string, int, []string) {
 		case "groups":
 			color.White("Administración de grupos: %s", command)
 
-			msg, err := disk.DiskExecuteCommanWithProps(command, parametros)
+			msg, err := disk.DiskExecuteCommanWithProps(ctx, command, parametros)
 			if err {
 				msgError := "[ERROR] " + msg
 				color.Red(msgError)
@@ -112,7 +112,7 @@ func GlobalCom(lista []string) ([]string, int, []string) {
 		case "users":
 			color.Yellow("Administración de usuarios: %s", command)
 
-			msg, err := disk.DiskExecuteCommanWithProps(command, parametros)
+			msg, err := disk.DiskExecuteCommanWithProps(ctx, command, parametros)
 			if err {
 				msgError := "[ERROR] " + msg
 				color.Red(msgError)
@@ -127,7 +127,7 @@ func GlobalCom(lista []string) ([]string, int, []string) {
 		case "files":
 			color.Green("Administración de archivos: %s", command)
 
-			msg, err := disk.DiskExecuteCommanWithProps(command, parametros)
+			msg, err := disk.DiskExecuteCommanWithProps(ctx, command, parametros)
 			if err {
 				msgError := "[ERROR] " + msg
 				color.Red(msgError)
@@ -142,7 +142,7 @@ func GlobalCom(lista []string) ([]string, int, []string) {
 		case "cat":
 			color.Blue("Comando CAT: %s", command)
 
-			msg, err := disk.DiskExecuteCommanWithProps(command, parametros)
+			msg, err := disk.DiskExecuteCommanWithProps(ctx, command, parametros)
 			if err {
 				msgError := "[ERROR] " + msg
 				color.Red(msgError)
@@ -39,6 +39,10 @@ type ResultadoAPI struct {
 	Error   bool        `json:"error"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data"` // Ahora puede contener []string con logs
+
+	// Token de la sesión del cliente tras ejecutar los comandos
+	// ("" si no hay sesión). Se omite en respuestas de error de petición.
+	Token *string `json:"token,omitempty"`
 }
 
 // ============================================
@@ -58,3 +62,11 @@ func ResultadoSalida(message string, isError bool, data interface{}) ResultadoAP
 		Data:    data,
 	}
 }
+
+// ResultadoSalidaSesion construye la respuesta incluyendo el token de
+// sesión vigente del cliente.
+func ResultadoSalidaSesion(message string, isError bool, data interface{}, token string) ResultadoAPI {
+	resultado := ResultadoSalida(message, isError, data)
+	resultado.Token = &token
+	return resultado
+}
@@ -27,6 +27,9 @@ func ObtenerParametros(x string) []string {
 			comandos = append(comandos, fmt.Sprintf("%s=%s", atributo, m[3]))
 		} else if m[4] != "" {
 			comandos = append(comandos, fmt.Sprintf("%s=%s", atributo, m[4]))
+		} else {
+			// Banderas sin valor (-p, -r)
+			comandos = append(comandos, fmt.Sprintf("%s=", atributo))
 		}
 	}
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -154,17 +155,32 @@ func NuevaPartitionVacia() structures.Partition {
 }
 
 /*
-SOLO SE PERMITEN PARTICIONES PRIMARIAS
+P = PRIMARIA | E = EXTENDIDA | L = LÓGICA
 */
 func TieneType(tipo string) (byte, bool, string) {
 	switch strings.ToUpper(strings.TrimSpace(tipo)) {
 	case "", "P":
 		return 'P', false, ""
+	case "E":
+		return 'E', false, ""
+	case "L":
+		return 'L', false, ""
 	default:
-		return 0, true, "Solo se permiten particiones primarias (P)"
+		return 0, true, "Tipo de partición inválido, solo se permite P, E o L"
 	}
 }
 
+func NuevoEBRVacio() structures.EBR {
+	e := structures.EBR{}
+	e.Part_mount = -1
+	e.Part_fit = 'W'
+	e.Part_start = -1
+	e.Part_s = 0
+	e.Part_next = -1
+	e.Part_correlative = -1
+	return e
+}
+
 /* =========================
    STRINGS
 ========================= */
@@ -215,35 +231,127 @@ func ObtenerEstructuraMBR(path string) (structures.MBR, bool, string) {
 	return mbr, false, ""
 }
 
+func EscribirEstructuraMBR(path string, mbr structures.MBR) (bool, string) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		return true, "Error al abrir el disco"
+	}
+	defer file.Close()
+
+	if err := binary.Write(file, binary.LittleEndian, &mbr); err != nil {
+		return true, "Error al escribir el MBR"
+	}
+
+	return false, ""
+}
+
 /* =========================
    ESPACIO
 ========================= */
 
-func ExisteEspacioDisponible(tamanio int32, pathDisco string, unidad byte, posicion int32) bool {
-	mbr, err, msg := ObtenerEstructuraMBR(pathDisco)
-	if err {
-		fmt.Println(msg)
-		return false
+// Segmento representa un rango contiguo de bytes dentro del disco
+type Segmento struct {
+	Inicio  int32
+	Tamanio int32
+}
+
+// calcularEspaciosLibres devuelve los huecos de [inicio, fin) que no
+// están cubiertos por los segmentos ocupados.
+func calcularEspaciosLibres(inicio int32, fin int32, ocupados []Segmento) []Segmento {
+	sort.Slice(ocupados, func(i, j int) bool {
+		return ocupados[i].Inicio < ocupados[j].Inicio
+	})
+
+	var libres []Segmento
+	actual := inicio
+
+	for _, o := range ocupados {
+		if o.Inicio > actual {
+			libres = append(libres, Segmento{Inicio: actual, Tamanio: o.Inicio - actual})
+		}
+		if o.Inicio+o.Tamanio > actual {
+			actual = o.Inicio + o.Tamanio
+		}
 	}
 
-	if posicion < 0 {
-		return false
+	if fin > actual {
+		libres = append(libres, Segmento{Inicio: actual, Tamanio: fin - actual})
 	}
 
-	tamanioDisco := ObtenerTamanioDisco(tamanio, unidad)
-	if tamanioDisco <= 0 {
-		return false
+	return libres
+}
+
+// EspaciosLibresDisco calcula los huecos del disco fuera del MBR y de las
+// particiones primarias/extendida.
+func EspaciosLibresDisco(mbr structures.MBR) []Segmento {
+	var ocupados []Segmento
+	for _, p := range mbr.Mbr_partitions {
+		if p.Part_start != -1 {
+			ocupados = append(ocupados, Segmento{Inicio: p.Part_start, Tamanio: p.Part_s})
+		}
 	}
+	return calcularEspaciosLibres(size.SizeMBR(), mbr.Mbr_tamano, ocupados)
+}
 
-	var espacio int32
-	if posicion == 0 {
-		espacio = mbr.Mbr_tamano - size.SizeMBR()
-	} else {
-		prev := mbr.Mbr_partitions[posicion-1]
-		espacio = mbr.Mbr_tamano - prev.Part_start - prev.Part_s
+// EspaciosLibresExtendida calcula los huecos dentro de la extendida. Cada
+// lógica ocupa su EBR más sus datos; si el EBR inicial está vacío su espacio
+// también se considera libre (una nueva lógica lo reutiliza).
+func EspaciosLibresExtendida(extendida structures.Partition, logicas []EBRUbicado) []Segmento {
+	var ocupados []Segmento
+	for _, l := range logicas {
+		if l.EBR.Part_s > 0 {
+			ocupados = append(ocupados, Segmento{
+				Inicio:  l.Posicion,
+				Tamanio: l.EBR.Part_start + l.EBR.Part_s - l.Posicion,
+			})
+		} else if l.Posicion != extendida.Part_start {
+			ocupados = append(ocupados, Segmento{Inicio: l.Posicion, Tamanio: size.SizeEBR()})
+		}
 	}
+	return calcularEspaciosLibres(extendida.Part_start, extendida.Part_start+extendida.Part_s, ocupados)
+}
 
-	return espacio >= tamanioDisco
+// SeleccionarEspacio aplica el ajuste (F = primer, B = mejor, W = peor)
+// sobre los huecos disponibles y devuelve el inicio elegido.
+func SeleccionarEspacio(libres []Segmento, tamanio int32, fit byte) (int32, bool) {
+	elegido := -1
+
+	for i, l := range libres {
+		if l.Tamanio < tamanio {
+			continue
+		}
+
+		switch fit {
+		case 'F':
+			return l.Inicio, true
+		case 'B':
+			if elegido == -1 || l.Tamanio < libres[elegido].Tamanio {
+				elegido = i
+			}
+		default:
+			if elegido == -1 || l.Tamanio > libres[elegido].Tamanio {
+				elegido = i
+			}
+		}
+	}
+
+	if elegido == -1 {
+		return -1, false
+	}
+
+	return libres[elegido].Inicio, true
+}
+
+// OrdenarParticiones deja las particiones del MBR ordenadas por inicio y
+// los espacios vacíos al final.
+func OrdenarParticiones(mbr *structures.MBR) {
+	sort.SliceStable(mbr.Mbr_partitions[:], func(i, j int) bool {
+		a, b := mbr.Mbr_partitions[i], mbr.Mbr_partitions[j]
+		if a.Part_start == -1 || b.Part_start == -1 {
+			return a.Part_start != -1 && b.Part_start == -1
+		}
+		return a.Part_start < b.Part_start
+	})
 }
 
 /* =====================================================
@@ -289,5 +397,91 @@ func ExisteNombreParticion(path string, nombre string) (bool, string) {
 		}
 	}
 
+	extendida, existe := ObtenerParticionExtendida(mbr)
+	if !existe {
+		return false, ""
+	}
+
+	logicas, err, msg := ObtenerListaEBR(path, extendida)
+	if err {
+		return true, msg
+	}
+
+	for _, l := range logicas {
+		if l.EBR.Part_s > 0 && strings.EqualFold(ConvertirByteAString(l.EBR.Name[:]), nombre) {
+			return true, "Ya existe una partición lógica con ese nombre"
+		}
+	}
+
+	return false, ""
+}
+
+/* =========================
+   EBR (PARTICIONES LÓGICAS)
+========================= */
+
+// EBRUbicado asocia un EBR con la posición del disco donde está escrito
+type EBRUbicado struct {
+	Posicion int32
+	EBR      structures.EBR
+}
+
+func ObtenerParticionExtendida(mbr structures.MBR) (structures.Partition, bool) {
+	for _, part := range mbr.Mbr_partitions {
+		if part.Part_start != -1 && part.Part_type == 'E' {
+			return part, true
+		}
+	}
+	return structures.Partition{}, false
+}
+
+// ObtenerListaEBR recorre la cadena de EBR desde el inicio de la extendida.
+// El primer EBR siempre existe; si su Part_s es 0 no contiene partición lógica.
+func ObtenerListaEBR(path string, extendida structures.Partition) ([]EBRUbicado, bool, string) {
+	var lista []EBRUbicado
+
+	file, err := os.Open(path)
+	if err != nil {
+		return lista, true, "Error al abrir el disco"
+	}
+	defer file.Close()
+
+	limite := extendida.Part_start + extendida.Part_s
+	pos := extendida.Part_start
+
+	for pos != -1 {
+		if pos < extendida.Part_start || pos+size.SizeEBR() > limite || len(lista) > int(extendida.Part_s/size.SizeEBR()) {
+			return lista, true, "La cadena de EBR está corrupta"
+		}
+
+		var ebr structures.EBR
+		if _, err := file.Seek(int64(pos), 0); err != nil {
+			return lista, true, "Error al posicionar el EBR"
+		}
+		if err := binary.Read(file, binary.LittleEndian, &ebr); err != nil {
+			return lista, true, "Error al leer el EBR"
+		}
+
+		lista = append(lista, EBRUbicado{Posicion: pos, EBR: ebr})
+		pos = ebr.Part_next
+	}
+
+	return lista, false, ""
+}
+
+func EscribirEBR(path string, pos int32, ebr structures.EBR) (bool, string) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		return true, "Error al abrir el disco"
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(int64(pos), 0); err != nil {
+		return true, "Error al posicionar el EBR"
+	}
+	if err := binary.Write(file, binary.LittleEndian, &ebr); err != nil {
+		return true, "Error al escribir el EBR"
+	}
+
 	return false, ""
 }
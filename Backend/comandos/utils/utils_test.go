@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalcularEspaciosLibres(t *testing.T) {
+	tests := []struct {
+		name     string
+		ocupados []Segmento
+		want     []Segmento
+	}{
+		{
+			name: "disco vacío",
+			want: []Segmento{{Inicio: 100, Tamanio: 900}},
+		},
+		{
+			name:     "huecos antes, entre y después",
+			ocupados: []Segmento{{Inicio: 600, Tamanio: 100}, {Inicio: 200, Tamanio: 100}},
+			want: []Segmento{
+				{Inicio: 100, Tamanio: 100},
+				{Inicio: 300, Tamanio: 300},
+				{Inicio: 700, Tamanio: 300},
+			},
+		},
+		{
+			name:     "segmentos contiguos",
+			ocupados: []Segmento{{Inicio: 100, Tamanio: 400}, {Inicio: 500, Tamanio: 500}},
+		},
+		{
+			name:     "segmentos superpuestos",
+			ocupados: []Segmento{{Inicio: 100, Tamanio: 300}, {Inicio: 200, Tamanio: 100}},
+			want:     []Segmento{{Inicio: 400, Tamanio: 600}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calcularEspaciosLibres(100, 1000, tt.ocupados)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calcularEspaciosLibres() = %v, se esperaba %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeleccionarEspacio(t *testing.T) {
+	libres := []Segmento{
+		{Inicio: 0, Tamanio: 50},
+		{Inicio: 100, Tamanio: 300},
+		{Inicio: 500, Tamanio: 120},
+		{Inicio: 700, Tamanio: 800},
+	}
+
+	tests := []struct {
+		name    string
+		tamanio int32
+		fit     byte
+		want    int32
+		ok      bool
+	}{
+		{"primer ajuste", 100, 'F', 100, true},
+		{"primer ajuste exacto", 50, 'F', 0, true},
+		{"mejor ajuste", 100, 'B', 500, true},
+		{"mejor ajuste exacto", 300, 'B', 100, true},
+		{"peor ajuste", 100, 'W', 700, true},
+		{"peor ajuste con un solo hueco válido", 500, 'W', 700, true},
+		{"sin espacio (primer)", 900, 'F', -1, false},
+		{"sin espacio (mejor)", 900, 'B', -1, false},
+		{"sin espacio (peor)", 900, 'W', -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := SeleccionarEspacio(libres, tt.tamanio, tt.fit)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("SeleccionarEspacio(%d, %c) = (%d, %v), se esperaba (%d, %v)",
+					tt.tamanio, tt.fit, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	if _, ok := SeleccionarEspacio(nil, 1, 'F'); ok {
+		t.Error("SeleccionarEspacio() sin huecos no debería encontrar espacio")
+	}
+}
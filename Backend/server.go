@@ -1,11 +1,15 @@
 package main
 
 import (
+	"Proyecto/comandos/commandGroups/disk"
 	"Proyecto/comandos/controllers"
 	"Proyecto/comandos/general"
 	"Proyecto/middlewares"
 	"fmt"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/rs/cors"
 )
@@ -31,6 +35,12 @@ func main() {
 
 	fmt.Println("" + fmt.Sprintf("Backend server is on %v", puerto))
 	general.CrearCarpeta()
+	disk.CargarParticionesMontadas()
+
+	// Minutos de inactividad antes de expirar una sesión
+	if minutos, err := strconv.Atoi(os.Getenv("SESSION_IDLE_MINUTES")); err == nil && minutos > 0 {
+		disk.SessionIdleTimeout = time.Duration(minutos) * time.Minute
+	}
 	// obtencionpf.ObtenerMBR_Mounted()
 	// obtencionpf.MostrarParticionesMontadas()
 	// http.ListenAndServe(":8080", handler)